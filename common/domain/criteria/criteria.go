@@ -1,5 +1,20 @@
 package criteria
 
+// Criteria describe una consulta: Filters es una lista plana de condiciones
+// unidas por AND y Where un árbol de condiciones que se combina con ellas.
 type Criteria struct {
 	Filters Filters
+	Where   *Expression
+	Orders  []Order
+	Limit   int
+	Offset  int
+}
+
+// Expression devuelve todas las condiciones del criteria como un único árbol.
+func (c Criteria) Expression() Expression {
+	expression := FromFilters(c.Filters.Get())
+	if c.Where != nil {
+		expression.Expressions = append(expression.Expressions, *c.Where)
+	}
+	return expression
 }
@@ -0,0 +1,67 @@
+package criteria
+
+// Logic representa el operador lógico de un grupo de expresiones.
+type Logic string
+
+const (
+	LogicAnd Logic = "AND"
+	LogicOr  Logic = "OR"
+	LogicNot Logic = "NOT"
+)
+
+// Expression es un nodo del árbol de condiciones. Una hoja contiene un Filter;
+// un grupo combina sus Expressions con Logic (NOT niega su única expresión).
+type Expression struct {
+	Logic       Logic        `json:"logic,omitempty"`
+	Filter      *Filter      `json:"filter,omitempty"`
+	Expressions []Expression `json:"expressions,omitempty"`
+}
+
+// Where crea una hoja con una única condición.
+func Where(field FilterField, operator Operator, value interface{}) Expression {
+	return Expression{
+		Filter: &Filter{
+			Field:    field,
+			Operator: operator,
+			Value:    value,
+		},
+	}
+}
+
+func And(expressions ...Expression) Expression {
+	return Expression{Logic: LogicAnd, Expressions: expressions}
+}
+
+func Or(expressions ...Expression) Expression {
+	return Expression{Logic: LogicOr, Expressions: expressions}
+}
+
+func Not(expression Expression) Expression {
+	return Expression{Logic: LogicNot, Expressions: []Expression{expression}}
+}
+
+// FromFilters agrupa una lista plana de filtros en un AND.
+func FromFilters(filters []Filter) Expression {
+	expressions := make([]Expression, len(filters))
+	for i, f := range filters {
+		expressions[i] = Where(f.Field, f.Operator, f.Value)
+	}
+	return And(expressions...)
+}
+
+func (e Expression) IsLeaf() bool {
+	return e.Filter != nil
+}
+
+// IsEmpty indica si la expresión no tiene ninguna condición.
+func (e Expression) IsEmpty() bool {
+	if e.IsLeaf() {
+		return false
+	}
+	for _, child := range e.Expressions {
+		if !child.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
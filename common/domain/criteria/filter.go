@@ -1,5 +1,7 @@
 package criteria
 
+import "reflect"
+
 // FilterField representa el campo sobre el que se aplica el filtro.
 type FilterField string
 
@@ -23,8 +25,51 @@ const (
 	OperatorNotLike      Operator = "NOT LIKE"
 	OperatorIn           Operator = "IN"
 	OperatorNotIn        Operator = "NOT IN"
+
+	// OperatorExists compara la existencia del campo con el valor (bool).
+	OperatorExists Operator = "EXISTS"
+	// OperatorBetween requiere un Range (o un slice de dos valores), ambos extremos inclusivos.
+	OperatorBetween Operator = "BETWEEN"
+
+	// Operadores sobre campos de tipo arreglo.
+	OperatorContains    Operator = "CONTAINS"
+	OperatorContainsAll Operator = "CONTAINS ALL"
+	OperatorContainsAny Operator = "CONTAINS ANY"
+
+	// OperatorElemMatch aplica un conjunto de filtros ([]Filter) o una
+	// Expression sobre un mismo elemento de un campo de tipo arreglo.
+	OperatorElemMatch Operator = "ELEM MATCH"
 )
 
+// Range es el valor del operador BETWEEN.
+type Range struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+func Between(from interface{}, to interface{}) Range {
+	return Range{From: from, To: to}
+}
+
+// RangeOf obtiene los extremos del valor de un BETWEEN.
+func RangeOf(value interface{}) (Range, bool) {
+	switch v := value.(type) {
+	case Range:
+		return v, true
+	case *Range:
+		if v != nil {
+			return *v, true
+		}
+	}
+
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Len() == 2 {
+		return Range{From: rv.Index(0).Interface(), To: rv.Index(1).Interface()}, true
+	}
+
+	return Range{}, false
+}
+
 // Filter representa una condición de filtro utilizando genéricos.
 type Filter struct {
 	Field    FilterField `json:"field"`
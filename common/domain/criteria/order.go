@@ -0,0 +1,26 @@
+package criteria
+
+// OrderType representa la dirección del ordenamiento.
+type OrderType string
+
+const (
+	OrderTypeAsc  OrderType = "ASC"
+	OrderTypeDesc OrderType = "DESC"
+)
+
+// Order representa un criterio de ordenamiento sobre un campo.
+type Order struct {
+	Field FilterField `json:"field"`
+	Type  OrderType   `json:"type"`
+}
+
+func NewOrder(field FilterField, orderType OrderType) Order {
+	return Order{
+		Field: field,
+		Type:  orderType,
+	}
+}
+
+func (o Order) IsDesc() bool {
+	return o.Type == OrderTypeDesc
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -58,6 +60,9 @@ func NewMongoRepository[T domain.IEntity, L domain.IEntity](uri string, dbName s
 func (m *MongoRepository[T, L]) Save(ctx context.Context, document T) utils.Result[string] {
 	result, err := m.Collection.InsertOne(ctx, document)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return utils.Result[string]{Err: cerrs.NewCustomError(http.StatusConflict, err.Error(), "mongo.save.duplicate_key")}
+		}
 		return utils.Result[string]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.save")}
 	}
 
@@ -179,10 +184,56 @@ func (m *MongoRepository[T, L]) UpdateFields(ctx context.Context, id string, upd
 	return utils.Result[T]{Data: updated}
 }
 
-// Delete elimina un documento de la colección usando el id proporcionado.
+// UpdateFieldsIf aplica los updates solo si el documento cumple además la
+// condición where, de forma atómica. Si no la cumple (o no existe) devuelve un
+// error 409 con scope mongo.update_fields.conflict.
+func (m *MongoRepository[T, L]) UpdateFieldsIf(ctx context.Context, id string, where criteria.Expression, updates map[string]interface{}) utils.Result[T] {
+
+	entry := logger.FromContext(ctx)
+
+	var updated T
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.Result[T]{Err: cerrs.NewCustomError(http.StatusInternalServerError, fmt.Errorf("id inválido (%s): %w", id, err).Error(), "mongo.update_fields")}
+	}
+
+	if len(updates) == 0 {
+		return utils.Result[T]{Err: cerrs.NewCustomError(http.StatusInternalServerError, "no se proporcionaron campos para actualizar", "mongo.update_fields")}
+	}
+
+	filter := bson.M{"_id": oid}
+	if !where.IsEmpty() {
+		filter = bson.M{"$and": bson.A{filter, buildExpression(where)}}
+	}
+
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetUpsert(false)
+
+	err = m.Collection.FindOneAndUpdate(ctx, filter, bson.M{"$set": updates}, opts).Decode(&updated)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			entry.Errorf("el documento %s no cumple la condición de la actualización", id)
+			return utils.Result[T]{Err: cerrs.NewCustomError(http.StatusConflict, fmt.Sprintf("el documento %s no cumple la condición de la actualización", id), "mongo.update_fields.conflict")}
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return utils.Result[T]{Err: cerrs.NewCustomError(http.StatusConflict, err.Error(), "mongo.update_fields.duplicate_key")}
+		}
+		return utils.Result[T]{Err: cerrs.NewCustomError(http.StatusInternalServerError, fmt.Errorf("error al devolver el documento actualizado: %w", err).Error(), "mongo.update_fields")}
+	}
+
+	return utils.Result[T]{Data: updated}
+}
+
+// Delete elimina un documento de la colección usando el id proporcionado. El
+// id puede ser un ObjectID en hexadecimal (Save) o el _id de SaveWithID.
 func (m *MongoRepository[T, L]) Delete(ctx context.Context, id string) error {
 	// Crear el filtro usando _id
 	filter := bson.M{"_id": id}
+	if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+		filter = bson.M{"_id": oid}
+	}
 
 	// Ejecutar la eliminación
 	result, err := m.Collection.DeleteOne(ctx, filter)
@@ -238,20 +289,14 @@ func (m *MongoRepository[T, L]) FindAll(ctx context.Context) utils.Result[[]L] {
 
 func (m *MongoRepository[T, L]) Matching(cr criteria.Criteria, table_name string, offset int, limit int) utils.Result[[]L] {
 	// Construir el filtro BSON basado en los criterios
-	filter := bson.M{}
-
-	// Recorrer los filtros para construir el filtro BSON
-	for _, f := range cr.Filters.Get() {
-		// Convertir el operador SQL a operador MongoDB
-		mongoOperator := convertSQLOperatorToMongo(f.Operator, f.Value)
-
-		// Aplicar el filtro al campo correspondiente
-		if mongoOperator != nil {
-			filter[string(f.Field)] = mongoOperator
-		} else {
-			// Para operadores simples como igualdad
-			filter[string(f.Field)] = f.Value
-		}
+	filter := buildExpression(cr.Expression())
+
+	// Los parámetros explícitos tienen prioridad sobre la paginación del criteria
+	if offset <= 0 {
+		offset = cr.Offset
+	}
+	if limit <= 0 {
+		limit = cr.Limit
 	}
 
 	// Configurar opciones de búsqueda con paginación
@@ -262,6 +307,9 @@ func (m *MongoRepository[T, L]) Matching(cr criteria.Criteria, table_name string
 	if limit > 0 {
 		opts.SetLimit(int64(limit))
 	}
+	if len(cr.Orders) > 0 {
+		opts.SetSort(buildSort(cr.Orders))
+	}
 
 	// Ejecutar la consulta
 	cursor, err := m.Collection.Find(context.Background(), filter, opts)
@@ -279,6 +327,150 @@ func (m *MongoRepository[T, L]) Matching(cr criteria.Criteria, table_name string
 	return utils.Result[[]L]{Data: entities}
 }
 
+// Each recorre con un cursor los documentos que cumplen el criteria, en su
+// orden, sin cargarlos todos en memoria. Se detiene con el primer error de fn,
+// que se devuelve tal cual.
+func (m *MongoRepository[T, L]) Each(ctx context.Context, cr criteria.Criteria, fn func(T) error) error {
+
+	opts := options.Find()
+	if cr.Offset > 0 {
+		opts.SetSkip(int64(cr.Offset))
+	}
+	if cr.Limit > 0 {
+		opts.SetLimit(int64(cr.Limit))
+	}
+	if len(cr.Orders) > 0 {
+		opts.SetSort(buildSort(cr.Orders))
+	}
+
+	cursor, err := m.Collection.Find(ctx, buildExpression(cr.Expression()), opts)
+	if err != nil {
+		return cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.each.find")
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var document T
+		if err := cursor.Decode(&document); err != nil {
+			return cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.each.decode")
+		}
+		if err := fn(document); err != nil {
+			return err
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.each.cursor")
+	}
+
+	return nil
+}
+
+// Count devuelve el número de documentos que cumplen los filtros del criteria,
+// ignorando su ordenamiento y paginación.
+func (m *MongoRepository[T, L]) Count(ctx context.Context, cr criteria.Criteria) utils.Result[int64] {
+	total, err := m.Collection.CountDocuments(ctx, buildExpression(cr.Expression()))
+	if err != nil {
+		return utils.Result[int64]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "mongo.count")}
+	}
+
+	return utils.Result[int64]{Data: total}
+}
+
+// CreateIndexes crea los índices indicados; si ya existen no hace nada.
+func (m *MongoRepository[T, L]) CreateIndexes(ctx context.Context, models ...mongo.IndexModel) error {
+	if _, err := m.Collection.Indexes().CreateMany(ctx, models); err != nil {
+		return cerrs.NewCustomError(http.StatusInternalServerError, fmt.Errorf("error al crear los índices: %w", err).Error(), "mongo.create_indexes")
+	}
+	return nil
+}
+
+// buildFilter convierte una lista plana de filtros en un filtro BSON
+func buildFilter(filters []criteria.Filter) bson.M {
+	return buildExpression(criteria.FromFilters(filters))
+}
+
+// buildExpression convierte el árbol de condiciones en un filtro BSON
+func buildExpression(e criteria.Expression) bson.M {
+
+	if e.IsLeaf() {
+		return buildCondition(*e.Filter)
+	}
+
+	var children []bson.M
+	for _, child := range e.Expressions {
+		if child.IsEmpty() {
+			continue
+		}
+		children = append(children, buildExpression(child))
+	}
+
+	switch e.Logic {
+	case criteria.LogicOr:
+		if len(children) == 0 {
+			return bson.M{}
+		}
+		if len(children) == 1 {
+			return children[0]
+		}
+		return bson.M{"$or": children}
+	case criteria.LogicNot:
+		if len(children) == 0 {
+			return bson.M{}
+		}
+		return bson.M{"$nor": []bson.M{mergeAnd(children)}}
+	default:
+		return mergeAnd(children)
+	}
+}
+
+// mergeAnd combina las condiciones en un único documento mientras no repitan
+// campos; si dos condiciones usan el mismo campo se agrupan con $and para que
+// ninguna sobrescriba a la otra.
+func mergeAnd(children []bson.M) bson.M {
+	if len(children) == 1 {
+		return children[0]
+	}
+
+	merged := bson.M{}
+	for _, child := range children {
+		for key, value := range child {
+			if _, exists := merged[key]; exists {
+				return bson.M{"$and": children}
+			}
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// buildCondition convierte una hoja del árbol en un filtro BSON
+func buildCondition(f criteria.Filter) bson.M {
+	// Convertir el operador SQL a operador MongoDB
+	mongoOperator := convertSQLOperatorToMongo(f.Operator, f.Value)
+
+	// Aplicar el filtro al campo correspondiente
+	if mongoOperator != nil {
+		return bson.M{string(f.Field): mongoOperator}
+	}
+
+	// Para operadores simples como igualdad
+	return bson.M{string(f.Field): f.Value}
+}
+
+// buildSort convierte los ordenamientos del criteria en un documento de sort
+func buildSort(orders []criteria.Order) bson.D {
+	sort := bson.D{}
+	for _, o := range orders {
+		direction := 1
+		if o.IsDesc() {
+			direction = -1
+		}
+		sort = append(sort, bson.E{Key: string(o.Field), Value: direction})
+	}
+	return sort
+}
+
 // convertSQLOperatorToMongo convierte operadores SQL a operadores MongoDB
 func convertSQLOperatorToMongo(operator criteria.Operator, value interface{}) interface{} {
 	switch operator {
@@ -297,13 +489,13 @@ func convertSQLOperatorToMongo(operator criteria.Operator, value interface{}) in
 	case criteria.OperatorLike:
 		// Convertir LIKE a regex de MongoDB
 		if strValue, ok := value.(string); ok {
-			return bson.M{"$regex": strValue, "$options": "i"}
+			return bson.M{"$regex": likeToRegex(strValue), "$options": "i"}
 		}
 		return value
 	case criteria.OperatorNotLike:
 		// Convertir NOT LIKE a regex de MongoDB
 		if strValue, ok := value.(string); ok {
-			return bson.M{"$not": bson.M{"$regex": strValue, "$options": "i"}}
+			return bson.M{"$not": bson.M{"$regex": likeToRegex(strValue), "$options": "i"}}
 		}
 		return bson.M{"$ne": value}
 	case criteria.OperatorIn:
@@ -312,7 +504,62 @@ func convertSQLOperatorToMongo(operator criteria.Operator, value interface{}) in
 	case criteria.OperatorNotIn:
 		// Para NOT IN, el valor debe ser un slice
 		return bson.M{"$nin": value}
+	case criteria.OperatorExists:
+		exists, ok := value.(bool)
+		if !ok {
+			exists = true
+		}
+		return bson.M{"$exists": exists}
+	case criteria.OperatorBetween:
+		if r, ok := criteria.RangeOf(value); ok {
+			return bson.M{"$gte": r.From, "$lte": r.To}
+		}
+		return value
+	case criteria.OperatorContains:
+		// Un arreglo coincide por igualdad si alguno de sus elementos es el valor
+		return value
+	case criteria.OperatorContainsAll:
+		return bson.M{"$all": value}
+	case criteria.OperatorContainsAny:
+		return bson.M{"$in": value}
+	case criteria.OperatorElemMatch:
+		// Para ELEM MATCH, el valor debe ser un slice de filtros o una expresión
+		switch v := value.(type) {
+		case []criteria.Filter:
+			return bson.M{"$elemMatch": buildFilter(v)}
+		case criteria.Expression:
+			return bson.M{"$elemMatch": buildExpression(v)}
+		}
+		return value
 	default:
 		return value
 	}
 }
+
+// likeToRegex traduce un patrón LIKE a una expresión regular: % es cualquier
+// texto, _ un carácter y \ escapa el siguiente; el resto es literal. La
+// expresión no se ancla, como hasta ahora: LIKE busca el patrón en cualquier
+// parte del campo y "abc" equivale a "%abc%".
+func likeToRegex(pattern string) string {
+
+	var regex strings.Builder
+
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			regex.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			regex.WriteString(".*")
+		case r == '_':
+			regex.WriteString(".")
+		default:
+			regex.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	return regex.String()
+}
@@ -4,9 +4,7 @@ import (
 	"common/domain/criteria"
 	"common/utils"
 	"common/utils/cerrs"
-	"fmt"
 	"net/http"
-	"strings"
 )
 
 // MatchingLow realiza una consulta aplicando filtros definidos en criteria y devuelve las entidades resultantes.
@@ -23,8 +21,8 @@ import (
 // Proceso interno:
 //  1. Se obtiene el nombre de la tabla a partir del método TableName() del modelo.
 //  2. Se construye la consulta base "SELECT * FROM <tabla>".
-//  3. Se recorren los filtros obtenidos desde criteria.Criteria y se construye la cláusula WHERE utilizando
-//     marcadores numerados para cada filtro.
+//  3. Se recorre el árbol de condiciones (Filters y Where) de criteria.Criteria y se construye la cláusula
+//     WHERE utilizando marcadores numerados para cada valor, seguida de ORDER BY, LIMIT y OFFSET.
 //  4. Se ejecuta la consulta utilizando la conexión r.Conn y se obtienen las filas resultantes.
 //  5. Se extraen los nombres de las columnas para mapear cada fila a una entidad mediante el método rowsToEntity.
 //  6. Se acumulan las entidades en un slice que se retorna dentro de utils.Result[[]E].
@@ -44,19 +42,35 @@ func (r *PostgresRepository[E, M, Q]) MatchingLow(cr criteria.Criteria, table_na
 	// Construir la consulta base.
 	queryStr := "SELECT * FROM " + tableName
 
-	var whereClauses []string
-	var args []interface{}
+	// Construir la cláusula WHERE a partir del árbol de condiciones usando marcadores numerados.
+	builder := &whereBuilder{}
 
-	// Recorrer los filtros para construir la cláusula WHERE usando marcadores numerados.
-	for i, f := range cr.Filters.Get() {
-		placeholder := fmt.Sprintf("$%d", i+1)
-		whereClauses = append(whereClauses, fmt.Sprintf("%s %s %s", f.Field, f.Operator, placeholder))
-		args = append(args, f.Value)
+	where, err := builder.expression(cr.Expression())
+	if err != nil {
+		return utils.Result[[]E]{Err: cerrs.NewCustomError(http.StatusBadRequest, err.Error(), "postgres.matching_low.build_where")}
+	}
+
+	if where != "" {
+		queryStr += " WHERE " + where
 	}
 
-	if len(whereClauses) > 0 {
-		queryStr += " WHERE " + strings.Join(whereClauses, " AND ")
+	queryStr += orderBy(cr.Orders)
+
+	// Los parámetros explícitos tienen prioridad sobre la paginación del criteria.
+	if offset <= 0 {
+		offset = cr.Offset
 	}
+	if limit <= 0 {
+		limit = cr.Limit
+	}
+	if limit > 0 {
+		queryStr += " LIMIT " + builder.placeholder(limit)
+	}
+	if offset > 0 {
+		queryStr += " OFFSET " + builder.placeholder(offset)
+	}
+
+	args := builder.args
 
 	// Ejecutar la consulta usando la conexión Conn.
 	rows, err := r.Conn.Query(queryStr, args...)
@@ -0,0 +1,107 @@
+package ppostgres
+
+import (
+	"common/domain/criteria"
+	"fmt"
+	"strings"
+)
+
+// whereBuilder traduce un criteria.Expression a SQL acumulando los argumentos
+// con marcadores numerados ($1, $2, ...).
+type whereBuilder struct {
+	args []interface{}
+}
+
+func (b *whereBuilder) placeholder(value interface{}) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *whereBuilder) expression(e criteria.Expression) (string, error) {
+
+	if e.IsLeaf() {
+		return b.condition(*e.Filter)
+	}
+
+	var clauses []string
+	for _, child := range e.Expressions {
+		if child.IsEmpty() {
+			continue
+		}
+		clause, err := b.expression(child)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, clause)
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+
+	switch e.Logic {
+	case criteria.LogicOr:
+		return "(" + strings.Join(clauses, " OR ") + ")", nil
+	case criteria.LogicNot:
+		return "NOT (" + strings.Join(clauses, " AND ") + ")", nil
+	default:
+		if len(clauses) == 1 {
+			return clauses[0], nil
+		}
+		return "(" + strings.Join(clauses, " AND ") + ")", nil
+	}
+}
+
+func (b *whereBuilder) condition(f criteria.Filter) (string, error) {
+
+	switch f.Operator {
+	case criteria.OperatorEqual,
+		criteria.OperatorNotEqual,
+		criteria.OperatorGreaterThan,
+		criteria.OperatorGreaterEqual,
+		criteria.OperatorLessThan,
+		criteria.OperatorLessEqual,
+		criteria.OperatorLike,
+		criteria.OperatorNotLike:
+		return fmt.Sprintf("%s %s %s", f.Field, f.Operator, b.placeholder(f.Value)), nil
+	case criteria.OperatorIn:
+		return fmt.Sprintf("%s = ANY(%s)", f.Field, b.placeholder(f.Value)), nil
+	case criteria.OperatorNotIn:
+		return fmt.Sprintf("NOT (%s = ANY(%s))", f.Field, b.placeholder(f.Value)), nil
+	case criteria.OperatorExists:
+		if exists, ok := f.Value.(bool); ok && !exists {
+			return fmt.Sprintf("%s IS NULL", f.Field), nil
+		}
+		return fmt.Sprintf("%s IS NOT NULL", f.Field), nil
+	case criteria.OperatorBetween:
+		r, ok := criteria.RangeOf(f.Value)
+		if !ok {
+			return "", fmt.Errorf("BETWEEN requires two values for field %s", f.Field)
+		}
+		return fmt.Sprintf("%s BETWEEN %s AND %s", f.Field, b.placeholder(r.From), b.placeholder(r.To)), nil
+	case criteria.OperatorContains:
+		return fmt.Sprintf("%s = ANY(%s)", b.placeholder(f.Value), f.Field), nil
+	case criteria.OperatorContainsAll:
+		return fmt.Sprintf("%s @> %s", f.Field, b.placeholder(f.Value)), nil
+	case criteria.OperatorContainsAny:
+		return fmt.Sprintf("%s && %s", f.Field, b.placeholder(f.Value)), nil
+	default:
+		return "", fmt.Errorf("operator %s is not supported by postgres", f.Operator)
+	}
+}
+
+// orderBy traduce los ordenamientos del criteria a una cláusula ORDER BY.
+func orderBy(orders []criteria.Order) string {
+	var clauses []string
+	for _, o := range orders {
+		direction := "ASC"
+		if o.IsDesc() {
+			direction = "DESC"
+		}
+		clauses = append(clauses, fmt.Sprintf("%s %s", o.Field, direction))
+	}
+	if len(clauses) == 0 {
+		return ""
+	}
+	return " ORDER BY " + strings.Join(clauses, ", ")
+}
@@ -0,0 +1,354 @@
+package cdtos
+
+import (
+	"common/domain/criteria"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldType indica cómo convertir el valor recibido en el query string.
+type FieldType string
+
+const (
+	FieldTypeString FieldType = "string"
+	FieldTypeInt    FieldType = "int"
+	FieldTypeFloat  FieldType = "float"
+	FieldTypeBool   FieldType = "bool"
+	FieldTypeDate   FieldType = "date"
+)
+
+// QueryOperators relaciona el nombre usado en el query string con el operador del criteria.
+var QueryOperators = map[string]criteria.Operator{
+	"eq":       criteria.OperatorEqual,
+	"ne":       criteria.OperatorNotEqual,
+	"gt":       criteria.OperatorGreaterThan,
+	"gte":      criteria.OperatorGreaterEqual,
+	"lt":       criteria.OperatorLessThan,
+	"lte":      criteria.OperatorLessEqual,
+	"like":     criteria.OperatorLike,
+	"nlike":    criteria.OperatorNotLike,
+	"in":       criteria.OperatorIn,
+	"nin":      criteria.OperatorNotIn,
+	"exists":   criteria.OperatorExists,
+	"between":  criteria.OperatorBetween,
+	"contains": criteria.OperatorContains,
+}
+
+// QueryField describe un campo que se puede filtrar u ordenar desde el query string.
+type QueryField struct {
+	// Column es el nombre del campo en la base de datos; por defecto la clave del schema.
+	Column    string
+	Type      FieldType
+	Operators []string
+	Sortable  bool
+}
+
+// QueryAlias es un parámetro corto equivalente a un filtro:
+// user_id=X equivale a filter[user_id][eq]=X.
+type QueryAlias struct {
+	Field    string
+	Operator string
+}
+
+// QuerySchema es la lista blanca de campos de un recurso.
+type QuerySchema struct {
+	Fields       map[string]QueryField
+	DefaultSort  []criteria.Order
+	DefaultLimit int
+	MaxLimit     int
+
+	// Params son los demás parámetros que lee el endpoint (p. ej. cursor).
+	Params []string
+	// Aliases son parámetros cortos que se leen como filtros.
+	Aliases map[string]QueryAlias
+}
+
+// paginationParams son los parámetros que ParseCriteria lee además de los filtros.
+var paginationParams = []string{"sort", "limit", "page", "offset"}
+
+var filterKeyRegex = regexp.MustCompile(`^filter\[([^\[\]]+)\](?:\[([^\[\]]+)\])?$`)
+
+// GetCriteriaWithResponse construye un criteria.Criteria a partir del query string.
+//
+//	?filter[title][like]=onboarding&filter[status]=published&sort=-created_at&limit=20&page=2
+func GetCriteriaWithResponse(ctx *gin.Context, cc *customctx.CustomContext, schema QuerySchema) utils.Response[criteria.Criteria] {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	cri, err := ParseCriteria(ctx.Request.URL.Query(), schema)
+	if err != nil {
+		entry.Error(err)
+		return utils.Response[criteria.Criteria]{
+			StatusCode: err.Code,
+			Success:    false,
+			Error:      cc.NewError(err),
+		}
+	}
+
+	return utils.Response[criteria.Criteria]{
+		Data:       cri,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// ParseCriteria convierte los parámetros filter[...], sus alias, sort, limit,
+// page y offset en un criteria.Criteria. Un parámetro que no sea ninguno de
+// estos ni esté en schema.Params responde 400.
+func ParseCriteria(values url.Values, schema QuerySchema) (criteria.Criteria, *cerrs.CustomError) {
+
+	var filters []criteria.Filter
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+
+		var name, operatorName string
+
+		if matches := filterKeyRegex.FindStringSubmatch(key); matches != nil {
+			name, operatorName = matches[1], matches[2]
+		} else if alias, ok := schema.Aliases[key]; ok {
+			name, operatorName = alias.Field, alias.Operator
+		} else if strings.HasPrefix(key, "filter") {
+			return criteria.Criteria{}, queryError("Invalid filter parameter: "+key, "query.invalid_filter")
+		} else if slices.Contains(paginationParams, key) || slices.Contains(schema.Params, key) {
+			continue
+		} else {
+			return criteria.Criteria{}, queryError("Unknown query parameter: "+key, "query.unknown_parameter")
+		}
+
+		parsed, err := parseFilters(name, operatorName, values[key], schema)
+		if err != nil {
+			return criteria.Criteria{}, err
+		}
+		filters = append(filters, parsed...)
+	}
+
+	orders, err := parseSort(values.Get("sort"), schema)
+	if err != nil {
+		return criteria.Criteria{}, err
+	}
+
+	limit, offset, err := parsePagination(values, schema)
+	if err != nil {
+		return criteria.Criteria{}, err
+	}
+
+	return criteria.Criteria{
+		Filters: *criteria.NewFilters(filters),
+		Orders:  orders,
+		Limit:   limit,
+		Offset:  offset,
+	}, nil
+}
+
+// parseFilters convierte los valores de un filtro comprobando que el campo y
+// el operador estén permitidos.
+func parseFilters(name string, operatorName string, raws []string, schema QuerySchema) ([]criteria.Filter, *cerrs.CustomError) {
+
+	if operatorName == "" {
+		operatorName = "eq"
+	}
+
+	field, ok := schema.Fields[name]
+	if !ok {
+		return nil, queryError("Unknown filter field: "+name, "query.invalid_field")
+	}
+
+	operator, ok := QueryOperators[operatorName]
+	if !ok || !slices.Contains(field.Operators, operatorName) {
+		return nil, queryError(fmt.Sprintf("Operator %s is not allowed for field %s", operatorName, name), "query.invalid_operator")
+	}
+
+	filters := make([]criteria.Filter, 0, len(raws))
+	for _, raw := range raws {
+		value, err := parseQueryValue(field.Type, operator, raw)
+		if err != nil {
+			return nil, queryError(fmt.Sprintf("Invalid value for %s: %s", name, err.Error()), "query.invalid_value")
+		}
+
+		filters = append(filters, criteria.Filter{
+			Field:    criteria.FilterField(field.column(name)),
+			Operator: operator,
+			Value:    value,
+		})
+	}
+
+	return filters, nil
+}
+
+func (f QueryField) column(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+func parseSort(raw string, schema QuerySchema) ([]criteria.Order, *cerrs.CustomError) {
+
+	if raw == "" {
+		return schema.DefaultSort, nil
+	}
+
+	var orders []criteria.Order
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+
+		orderType := criteria.OrderTypeAsc
+		if strings.HasPrefix(part, "-") {
+			orderType = criteria.OrderTypeDesc
+			part = strings.TrimPrefix(part, "-")
+		}
+
+		field, ok := schema.Fields[part]
+		if !ok || !field.Sortable {
+			return nil, queryError("Invalid sort field: "+part, "query.invalid_sort")
+		}
+
+		orders = append(orders, criteria.NewOrder(criteria.FilterField(field.column(part)), orderType))
+	}
+
+	return orders, nil
+}
+
+func parsePagination(values url.Values, schema QuerySchema) (int, int, *cerrs.CustomError) {
+
+	limit := schema.DefaultLimit
+	if raw := values.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || (schema.MaxLimit > 0 && parsed > schema.MaxLimit) {
+			if schema.MaxLimit > 0 {
+				return 0, 0, queryError(fmt.Sprintf("limit must be between 1 and %d", schema.MaxLimit), "query.invalid_limit")
+			}
+			return 0, 0, queryError("limit must be a positive integer", "query.invalid_limit")
+		}
+		limit = parsed
+	}
+
+	offset := 0
+	if raw := values.Get("offset"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return 0, 0, queryError("offset must be a positive integer", "query.invalid_offset")
+		}
+		offset = parsed
+	}
+
+	if raw := values.Get("page"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			return 0, 0, queryError("page must be a positive integer", "query.invalid_page")
+		}
+		offset = (parsed - 1) * limit
+	}
+
+	return limit, offset, nil
+}
+
+func parseQueryValue(fieldType FieldType, operator criteria.Operator, raw string) (interface{}, error) {
+
+	switch operator {
+	case criteria.OperatorExists:
+		return strconv.ParseBool(raw)
+	case criteria.OperatorIn, criteria.OperatorNotIn:
+		parts := strings.Split(raw, ",")
+		list := make([]interface{}, len(parts))
+		for i, part := range parts {
+			value, err := parseScalarValue(fieldType, strings.TrimSpace(part))
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+	case criteria.OperatorBetween:
+		parts := strings.Split(raw, ",")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("between requires two comma separated values")
+		}
+		from, err := parseScalarValue(fieldType, strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseBound(fieldType, strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, err
+		}
+		return criteria.Between(from, to), nil
+	case criteria.OperatorLessEqual:
+		return parseBound(fieldType, raw)
+	case criteria.OperatorLike, criteria.OperatorNotLike:
+		// El valor se busca como texto literal contenido en el campo: se
+		// escapan los comodines del patrón LIKE.
+		return "%" + likeEscaper.Replace(raw) + "%", nil
+	}
+
+	return parseScalarValue(fieldType, raw)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// parseBound lee el extremo superior de un rango: una fecha sin hora incluye
+// el día completo, de modo que lte=2025-09-30 incluye todo el 30.
+func parseBound(fieldType FieldType, raw string) (interface{}, error) {
+	if fieldType == FieldTypeDate {
+		return parseDate(raw, true)
+	}
+	return parseScalarValue(fieldType, raw)
+}
+
+// parseDate lee RFC3339 o YYYY-MM-DD; con endOfDay, una fecha sin hora es el
+// último instante del día (los timestamps se guardan con precisión de ms).
+func parseDate(raw string, endOfDay bool) (time.Time, error) {
+
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC3339 or YYYY-MM-DD")
+	}
+
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Millisecond)
+	}
+
+	return t, nil
+}
+
+func parseScalarValue(fieldType FieldType, raw string) (interface{}, error) {
+
+	switch fieldType {
+	case FieldTypeInt:
+		return strconv.Atoi(raw)
+	case FieldTypeFloat:
+		return strconv.ParseFloat(raw, 64)
+	case FieldTypeBool:
+		return strconv.ParseBool(raw)
+	case FieldTypeDate:
+		return parseDate(raw, false)
+	default:
+		return raw, nil
+	}
+}
+
+func queryError(message string, scope string) *cerrs.CustomError {
+	return cerrs.NewCustomError(http.StatusBadRequest, message, scope)
+}
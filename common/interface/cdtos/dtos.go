@@ -75,6 +75,35 @@ func GetDTOWithResponse[K DTO](ctx *gin.Context, cc *customctx.CustomContext) ut
 	}
 }
 
+// GetQueryDTOWithResponse igual que GetDTOWithResponse pero bindea los query params.
+func GetQueryDTOWithResponse[K DTO](ctx *gin.Context, cc *customctx.CustomContext) utils.Response[K] {
+
+	entry := logger.FromContext(ctx.Request.Context())
+
+	var dto K
+	if err := ctx.ShouldBindQuery(&dto); err != nil {
+		entry.Error(err)
+
+		response := makeResponseError(err, dto)
+
+		cc.NewError(response.Error)
+		return response
+	}
+	if err := dto.Validate(); err != nil {
+		entry.Error(err)
+
+		response := makeResponseError(err, dto)
+
+		cc.NewError(response.Error)
+		return response
+	}
+	return utils.Response[K]{
+		Data:       dto,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
 func makeResponseError[K DTO](err error, dto K) utils.Response[K] {
 
 	typ := reflect.TypeOf(dto)
@@ -0,0 +1,9 @@
+package utils
+
+// Pagination describe la página devuelta en un listado.
+type Pagination struct {
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit"`
+	Page       int    `json:"page,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
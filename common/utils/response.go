@@ -26,12 +26,13 @@ type Response[R any] struct {
 	Error      cerrs.CustomErrorInterface `json:"error,omitempty"`
 	StatusCode int                        `json:"status_code" default:"200"`
 
-	Data    R                            `json:"data,omitempty"`
-	Results []R                          `json:"results,omitempty"`
-	Alert   *Alert                       `json:"alert,omitempty"`
-	TraceID string                       `json:"trace_id,omitempty"`
-	Success bool                         `json:"success" default:"true"`
-	Errors  []cerrs.CustomErrorInterface `json:"errors,omitempty"`
+	Data       R                            `json:"data,omitempty"`
+	Results    []R                          `json:"results,omitempty"`
+	Pagination *Pagination                  `json:"pagination,omitempty"`
+	Alert      *Alert                       `json:"alert,omitempty"`
+	TraceID    string                       `json:"trace_id,omitempty"`
+	Success    bool                         `json:"success" default:"true"`
+	Errors     []cerrs.CustomErrorInterface `json:"errors,omitempty"`
 }
 
 func (r Response[R]) ToMapWithCustomContext(ctx *customctx.CustomContext) map[string]interface{} {
@@ -50,7 +51,11 @@ func (r Response[R]) ToMapWithCustomContext(ctx *customctx.CustomContext) map[st
 
 		if logger.LoggerConfig.ENVIRONMENT != "production" {
 
-			res["errors"] = ctx.Errors()
+			// Los errores de negocio de la respuesta (r.Errors) tienen prioridad
+			// sobre la traza del contexto.
+			if len(r.Errors) == 0 {
+				res["errors"] = ctx.Errors()
+			}
 			delete(res, "data")
 		}
 
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/forms"
+	"net/http"
+	"time"
+)
+
+// checkFormAcceptsAnswers valida que el formulario esté publicado y dentro de
+// su ventana de apertura y cierre. Las plantillas no reciben respuestas.
+func checkFormAcceptsAnswers(form forms.FormModel, now time.Time) *cerrs.CustomError {
+
+	if form.IsTemplate {
+		return cerrs.NewCustomError(
+			http.StatusForbidden,
+			"Templates do not accept answers",
+			"forms.create.answer.template",
+		)
+	}
+
+	if form.CurrentStatus() != entities.FormStatusPublished {
+		return cerrs.NewCustomError(
+			http.StatusForbidden,
+			"Form is not accepting answers: status is "+string(form.CurrentStatus()),
+			"forms.create.answer.not_published",
+		)
+	}
+
+	if form.OpensAt != nil && now.Before(*form.OpensAt) {
+		return cerrs.NewCustomError(
+			http.StatusForbidden,
+			"Form opens at "+form.OpensAt.Format(time.RFC3339),
+			"forms.create.answer.not_open",
+		)
+	}
+
+	if form.ClosesAt != nil && !now.Before(*form.ClosesAt) {
+		return cerrs.NewCustomError(
+			http.StatusForbidden,
+			"Form closed at "+form.ClosesAt.Format(time.RFC3339),
+			"forms.create.answer.closed",
+		)
+	}
+
+	return nil
+}
+
+// findFormAcceptingAnswers obtiene el formulario y comprueba que acepte respuestas.
+func (s *AnswerService) findFormAcceptingAnswers(cc *customctx.CustomContext, formID string) utils.Response[forms.FormModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	form := s.formsRepository.Find(cc.Context(), formID)
+
+	if form.Err != nil {
+		entry.Error("Error getting form", form.Err)
+		return utils.Response[forms.FormModel]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error:      form.Err,
+		}
+	}
+
+	if err := checkFormAcceptsAnswers(form.Data, time.Now().UTC()); err != nil {
+		entry.Error("Form is not accepting answers", err)
+		return utils.Response[forms.FormModel]{
+			StatusCode: err.Code,
+			Success:    false,
+			Error:      cc.NewError(err),
+		}
+	}
+
+	return utils.Response[forms.FormModel]{
+		Data:       form.Data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
@@ -8,11 +8,10 @@ import (
 	"fmt"
 	"fomrs/internal/api/v1/answers/domain/commands"
 	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/db/mongo/forms"
+	"fomrs/internal/db/mongo/history"
 	"net/http"
-
-	utils_internal "fomrs/internal/utils"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
 )
 
 func (s *AnswerService) Create(cc *customctx.CustomContext, command *commands.ResponseCommand) utils.Response[answers.AnswerModel] {
@@ -20,149 +19,194 @@ func (s *AnswerService) Create(cc *customctx.CustomContext, command *commands.Re
 	entry := logger.FromContext(cc.Context())
 
 	// Get Form
-	form := s.formsRepository.Find(cc.Context(), command.FormID)
+	form := s.findFormAcceptingAnswers(cc, command.FormID)
 
-	if form.Err != nil {
-		entry.Error("Error getting form", form.Err)
+	if form.Error != nil {
 		return utils.Response[answers.AnswerModel]{
-			StatusCode: http.StatusNotFound,
+			StatusCode: form.StatusCode,
 			Success:    false,
-			Error:      form.Err,
+			Error:      form.Error,
 		}
 	}
 
-	// Validate
+	// Submission policy
+	now := time.Now().UTC()
 
-	questions := form.Data.Questions
+	previous := s.checkSubmissionPolicy(cc, form.Data, command.UserID, now)
 
-	for _, question := range questions {
-		isRequired := question.Required
-		questionType := question.Type
-		questionID := question.ID
+	if previous.Error != nil {
+		return previous
+	}
 
-		var responseAnswer string
+	// Validate
 
-		for _, response := range command.Responses {
-			if questionID == response.QuestionID {
-				responseAnswer = response.Answer
-			}
+	validation := validateResponses(form.Data, command.Responses)
+
+	if validation.Err != nil {
+		entry.Error("Invalid question configuration", validation.Err)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: validation.Err.Code,
+			Success:    false,
+			Error:      cc.NewError(validation.Err),
 		}
+	}
 
-		if isRequired && responseAnswer == "" {
-			entry.Error("Question is required", cerrs.NewCustomError(http.StatusBadRequest, "Question is required", "create.answer"))
-			return utils.Response[answers.AnswerModel]{
-				StatusCode: http.StatusBadRequest,
-				Success:    false,
-				Error: cc.NewError(
-					cerrs.NewCustomError(
-						http.StatusBadRequest,
-						"Question is required: "+question.Title,
-						"forms.create.answer.required",
-					),
+	if len(validation.Errors) > 0 {
+		entry.Error("Invalid answers", validation.Errors)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusBadRequest,
+					fmt.Sprintf("Invalid answers: %d question(s) with errors", len(validation.Errors)),
+					"forms.create.answer.invalid",
 				),
-			}
+			),
+			Errors: validation.Errors,
 		}
+	}
 
-		if responseAnswer == "" {
-			continue
-		}
+	validation.Normalize(command.Responses)
 
-		validator := utils_internal.Validators[utils_internal.QuestionType(questionType)]
+	// Campos hidden y computed
+	responses, serverFields := populateServerFields(form.Data, command.Responses, command.Query, command.Context)
 
-		if questionType == string(utils_internal.QuestionTypeRadio) {
+	if serverFields.Err != nil {
+		entry.Error("Invalid server field configuration", serverFields.Err)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: serverFields.Err.Code,
+			Success:    false,
+			Error:      cc.NewError(serverFields.Err),
+		}
+	}
 
-			metadata := question.Metadata
+	if len(serverFields.Errors) > 0 {
+		entry.Error("Invalid server fields", serverFields.Errors)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusBadRequest,
+					fmt.Sprintf("Invalid answers: %d question(s) with errors", len(serverFields.Errors)),
+					"forms.create.answer.invalid",
+				),
+			),
+			Errors: serverFields.Errors,
+		}
+	}
 
-			options := metadata["options"].(primitive.A)
+	command.Responses = responses
 
-			optionsString := make([]string, len(options))
-			for i, option := range options {
-				optionsString[i] = option.(string)
-			}
+	if previous.Data.ID != "" {
+		return s.replaceAnswer(cc, previous.Data, form.Data, command, now)
+	}
 
-			validator = utils_internal.RadioValidator{
-				Options: optionsString,
-			}
+	// Insert Response
+	answer := answers.AnswerModel{
+		FormID:      command.FormID,
+		FormVersion: form.Data.CurrentVersion(),
+		UserID:      command.UserID,
+		ClientIP:    command.ClientIP,
+		UserAgent:   command.UserAgent,
+		Answers:     command.Responses,
+		Score:       scoreAnswers(form.Data, command.Responses),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+
+		SingleSubmission: form.Data.Settings.Submissions.SinglePerUser,
+	}
 
-			fmt.Println("options", options)
-		}
+	if reserved := reserveAnswer(cc, s.countersRepository, s.answersRepository, form.Data); reserved.Error != nil {
+		return reserved
+	}
 
-		if questionType == string(utils_internal.QuestionTypeSelect) {
+	res := s.answersRepository.Save(cc.Context(), answer)
 
-			metadata := question.Metadata
+	if res.Err != nil {
+		releaseAnswer(cc, s.countersRepository, form.Data.ID)
+	}
 
-			options := metadata["options"].(primitive.A)
+	// El índice único (form_id, user_id) resuelve los envíos simultáneos.
+	if res.Err != nil && res.Err.GetCode() == http.StatusConflict {
+		return policyError(cc, http.StatusConflict, "User already answered this form", "forms.create.answer.already_submitted")
+	}
 
-			optionsString := make([]string, len(options))
-			for i, option := range options {
-				optionsString[i] = option.(string)
-			}
+	if res.Err != nil {
+		entry.Error("Error saving answer", res.Err)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      res.Err,
+		}
+	}
 
-			validator = utils_internal.SelectValidator{
-				Options: optionsString,
-			}
+	answer.ID = res.Data
 
-			fmt.Println("options", options)
+	// max_total quedó resuelto con la reserva; la ventana se revisa con la
+	// respuesta guardada y, si sobra, se retira.
+	if limits := checkWindowRank(cc, s.answersRepository, form.Data, now, answer.ID); limits.Error != nil {
+		if err := s.answersRepository.Delete(cc.Context(), answer.ID); err != nil {
+			entry.Error("Error removing answer over the limit", err)
+		} else {
+			releaseAnswer(cc, s.countersRepository, form.Data.ID)
 		}
+		return limits
+	}
 
-		if questionType == string(utils_internal.QuestionTypeCheckbox) {
-
-			metadata := question.Metadata
+	return utils.Response[answers.AnswerModel]{
+		Data:       revealAnswers(form.Data, answer),
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
 
-			options := metadata["options"].(primitive.A)
+// replaceAnswer sustituye la respuesta anterior del usuario (latest_wins) y
+// registra el cambio en el historial.
+func (s *AnswerService) replaceAnswer(cc *customctx.CustomContext, previous answers.AnswerModel, form forms.FormModel, command *commands.ResponseCommand, now time.Time) utils.Response[answers.AnswerModel] {
 
-			optionsString := make([]string, len(options))
-			for i, option := range options {
-				optionsString[i] = option.(string)
-			}
+	entry := logger.FromContext(cc.Context())
 
-			validator = utils_internal.CheckboxValidator{
-				Options: optionsString,
-			}
+	entry.Info("Replacing previous answer: ", previous.ID)
 
-			fmt.Println("options", options)
-		}
+	updated := s.answersRepository.UpdateFields(cc.Context(), previous.ID, map[string]interface{}{
+		"answers":      command.Responses,
+		"score":        scoreAnswers(form, command.Responses),
+		"form_version": form.CurrentVersion(),
+		"client_ip":    command.ClientIP,
+		"user_agent":   command.UserAgent,
+		"updated_at":   now,
+	})
 
-		isValid := validator.IsValid(responseAnswer)
-
-		if !isValid {
-			entry.Error("Invalid answer", cerrs.NewCustomError(http.StatusBadRequest, "Invalid answer", "create.answer"))
-			return utils.Response[answers.AnswerModel]{
-				StatusCode: http.StatusBadRequest,
-				Success:    false,
-				Error: cc.NewError(
-					cerrs.NewCustomError(
-						http.StatusBadRequest,
-						"Invalid answer: ["+question.Title+"] "+validator.Description(),
-						"forms.create.answer.invalid",
-					),
-				),
-			}
+	if updated.Err != nil {
+		entry.Error("Error replacing answer", updated.Err)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(updated.Err),
 		}
-
-	}
-
-	// Insert Response
-	answer := answers.AnswerModel{
-		FormID:  command.FormID,
-		Answers: command.Responses,
 	}
 
-	res := s.answersRepository.Save(cc.Context(), answer)
-
-	if res.Err != nil {
-		entry.Error("Error saving answer", res.Err)
+	if res := s.saveHistory(cc, history.AnswerHistoryModel{
+		AnswerID:  previous.ID,
+		FormID:    previous.FormID,
+		Action:    history.AnswerActionUpdate,
+		Actor:     command.UserID,
+		ClientIP:  command.ClientIP,
+		Before:    previous.Answers,
+		After:     command.Responses,
+		CreatedAt: now,
+	}); res.Error != nil {
 		return utils.Response[answers.AnswerModel]{
-			StatusCode: http.StatusInternalServerError,
+			StatusCode: res.StatusCode,
 			Success:    false,
-			Error:      res.Err,
+			Error:      res.Error,
 		}
 	}
 
-	answer.ID = res.Data
-
 	return utils.Response[answers.AnswerModel]{
-		Data:       answer,
+		Data:       revealAnswers(form, updated.Data),
 		StatusCode: http.StatusOK,
 		Success:    true,
 	}
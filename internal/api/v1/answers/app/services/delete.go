@@ -0,0 +1,87 @@
+package services
+
+import (
+	"common/domain/criteria"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"fomrs/internal/api/v1/answers/domain/commands"
+	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/db/mongo/history"
+	"net/http"
+	"time"
+)
+
+// Delete elimina una respuesta de forma lógica: se marca deleted_at y deja de
+// listarse y recuperarse, pero el documento y su historial se conservan.
+func (s *AnswerService) Delete(cc *customctx.CustomContext, id string, command commands.DeleteAnswerCommand) utils.Response[answers.AnswerModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Deleting answer: ", id)
+
+	answer, _ := s.findEditableAnswer(cc, id)
+
+	if answer.Error != nil {
+		return answer
+	}
+
+	now := time.Now().UTC()
+	actor := actorOrOwner(command.Actor, answer.Data)
+
+	// Solo la primera de dos eliminaciones simultáneas libera el hueco del
+	// contador del formulario.
+	deleted := s.answersRepository.UpdateFieldsIf(cc.Context(), id, criteria.Where("deleted_at", criteria.OperatorExists, false), map[string]interface{}{
+		"deleted_at": now,
+		"deleted_by": actor,
+		// Libera el índice único para que el usuario pueda volver a responder.
+		"single_submission": false,
+		"updated_at":        now,
+	})
+
+	if deleted.Err != nil && deleted.Err.GetCode() == http.StatusConflict {
+		entry.Error("Answer was already deleted")
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(http.StatusNotFound, "Answer was deleted: "+id, "answers.retrieve.deleted"),
+			),
+		}
+	}
+
+	if deleted.Err != nil {
+		entry.Error("Error deleting answer", deleted.Err)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(deleted.Err),
+		}
+	}
+
+	releaseAnswer(cc, s.countersRepository, answer.Data.FormID)
+
+	if res := s.saveHistory(cc, history.AnswerHistoryModel{
+		AnswerID:  id,
+		FormID:    answer.Data.FormID,
+		Action:    history.AnswerActionDelete,
+		Actor:     actor,
+		ClientIP:  command.ClientIP,
+		Before:    answer.Data.Answers,
+		After:     nil,
+		CreatedAt: now,
+	}); res.Error != nil {
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: res.StatusCode,
+			Success:    false,
+			Error:      res.Error,
+		}
+	}
+
+	return utils.Response[answers.AnswerModel]{
+		Data:       deleted.Data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
@@ -0,0 +1,389 @@
+package services
+
+import (
+	"common/domain/criteria"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"fmt"
+	"fomrs/internal/api/v1/answers/domain/commands"
+	answersEntities "fomrs/internal/api/v1/answers/domain/entities"
+	formsEntities "fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/db/mongo/drafts"
+	"fomrs/internal/db/mongo/forms"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// CreateDraft inicia un borrador de respuesta. Las respuestas iniciales, si
+// las hay, se validan como una página sin sección.
+func (s *AnswerService) CreateDraft(cc *customctx.CustomContext, command commands.CreateDraftCommand) utils.Response[drafts.DraftModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Creating draft for form: ", command.FormID)
+
+	form := s.findFormAcceptingAnswers(cc, command.FormID)
+
+	if form.Error != nil {
+		return utils.Response[drafts.DraftModel]{
+			StatusCode: form.StatusCode,
+			Success:    false,
+			Error:      form.Error,
+		}
+	}
+
+	if res := s.validateDraftPage(cc, form.Data, command.Responses, command.Responses, ""); res.Error != nil {
+		return res
+	}
+
+	now := time.Now().UTC()
+
+	draft := drafts.DraftModel{
+		FormID:      command.FormID,
+		FormVersion: form.Data.CurrentVersion(),
+		UserID:      command.UserID,
+		Answers:     mergeAnswers(nil, command.Responses),
+		ExpiresAt:   now.Add(s.draftTTL),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	res := s.draftsRepository.Save(cc.Context(), draft)
+
+	if res.Err != nil {
+		entry.Error("Error saving draft", res.Err)
+		return utils.Response[drafts.DraftModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(res.Err),
+		}
+	}
+
+	draft.ID = res.Data
+
+	return utils.Response[drafts.DraftModel]{
+		Data:       draft,
+		StatusCode: http.StatusCreated,
+		Success:    true,
+	}
+}
+
+// RetrieveDraft devuelve el borrador para retomarlo.
+func (s *AnswerService) RetrieveDraft(cc *customctx.CustomContext, id string) utils.Response[drafts.DraftModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Retrieving draft: ", id)
+
+	return s.findOpenDraft(cc, id)
+}
+
+// UpdateDraft guarda una página del borrador: valida las respuestas enviadas
+// (y, si se indica la página, todas sus preguntas) y renueva la caducidad.
+func (s *AnswerService) UpdateDraft(cc *customctx.CustomContext, id string, command commands.UpdateDraftCommand) utils.Response[drafts.DraftModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Updating draft: ", id)
+
+	draft := s.findOpenDraft(cc, id)
+
+	if draft.Error != nil {
+		return draft
+	}
+
+	form := s.findFormAcceptingAnswers(cc, draft.Data.FormID)
+
+	if form.Error != nil {
+		return utils.Response[drafts.DraftModel]{
+			StatusCode: form.StatusCode,
+			Success:    false,
+			Error:      form.Error,
+		}
+	}
+
+	merged := mergeAnswers(draft.Data.Answers, command.Responses)
+
+	if res := s.validateDraftPage(cc, form.Data, command.Responses, merged, command.Page); res.Error != nil {
+		return res
+	}
+
+	now := time.Now().UTC()
+
+	updated := s.draftsRepository.UpdateFields(cc.Context(), id, map[string]interface{}{
+		"answers":      merged,
+		"form_version": form.Data.CurrentVersion(),
+		"expires_at":   now.Add(s.draftTTL),
+		"updated_at":   now,
+	})
+
+	if updated.Err != nil {
+		entry.Error("Error updating draft", updated.Err)
+		return utils.Response[drafts.DraftModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(updated.Err),
+		}
+	}
+
+	return utils.Response[drafts.DraftModel]{
+		Data:       updated.Data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// SubmitDraft envía el borrador con la validación completa de Create y, si se
+// acepta, lo marca como enviado. Antes de crear la respuesta el borrador se
+// reserva con una actualización condicional: de dos envíos simultáneos (o un
+// reintento) solo uno crea la respuesta y el otro recibe 409. Una reserva de
+// más de drafts.SubmitTimeout se da por interrumpida y puede reclamarse. Los
+// borradores con user_id solo los puede enviar ese usuario.
+func (s *AnswerService) SubmitDraft(cc *customctx.CustomContext, id string, command commands.SubmitDraftCommand) utils.Response[answers.AnswerModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Submitting draft: ", id)
+
+	draft := s.findOpenDraft(cc, id)
+
+	if draft.Error != nil {
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: draft.StatusCode,
+			Success:    false,
+			Error:      draft.Error,
+		}
+	}
+
+	if draft.Data.UserID != "" && command.Actor != draft.Data.UserID {
+		entry.Error("Draft belongs to another user")
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(http.StatusForbidden, "Draft belongs to another user", "answers.drafts.forbidden"),
+			),
+		}
+	}
+
+	// Mongo guarda las fechas con milisegundos: se trunca para poder liberar
+	// la reserva comparando con el valor guardado.
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	claimed := s.draftsRepository.UpdateFieldsIf(cc.Context(), id,
+		criteria.And(
+			criteria.Where("submitted_answer_id", criteria.OperatorExists, false),
+			criteria.Or(
+				criteria.Where("submitting_at", criteria.OperatorEqual, nil),
+				criteria.Where("submitting_at", criteria.OperatorLessThan, now.Add(-drafts.SubmitTimeout)),
+			),
+		),
+		map[string]interface{}{
+			"submitting_at": now,
+		},
+	)
+
+	if claimed.Err != nil {
+		entry.Error("Error claiming draft", claimed.Err)
+		if claimed.Err.GetCode() == http.StatusConflict {
+			return utils.Response[answers.AnswerModel]{
+				StatusCode: http.StatusConflict,
+				Success:    false,
+				Error: cc.NewError(
+					cerrs.NewCustomError(http.StatusConflict, "Draft is already being submitted", "answers.drafts.submitting"),
+				),
+			}
+		}
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(claimed.Err),
+		}
+	}
+
+	res := s.Create(cc, &commands.ResponseCommand{
+		FormID:    draft.Data.FormID,
+		UserID:    draft.Data.UserID,
+		Responses: draft.Data.Answers,
+		ClientIP:  command.ClientIP,
+		UserAgent: command.UserAgent,
+		Query:     command.Query,
+		Context:   command.Context,
+	})
+
+	if !res.Success {
+		// La respuesta no se creó: se libera el borrador para corregirlo, salvo
+		// que otro envío lo haya reclamado tras SubmitTimeout.
+		if released := s.draftsRepository.UpdateFieldsIf(cc.Context(), id,
+			criteria.Where("submitting_at", criteria.OperatorEqual, now),
+			map[string]interface{}{
+				"submitting_at": nil,
+			},
+		); released.Err != nil {
+			entry.Error("Error releasing draft", released.Err)
+		}
+		return res
+	}
+
+	// La respuesta ya está guardada y el borrador sigue reservado, así que un
+	// fallo al marcarlo no permite enviarlo otra vez; solo se registra.
+	marked := s.draftsRepository.UpdateFields(cc.Context(), id, map[string]interface{}{
+		"submitted_answer_id": res.Data.ID,
+		"updated_at":          time.Now().UTC(),
+	})
+
+	if marked.Err != nil {
+		entry.Error("Error marking draft as submitted", marked.Err)
+	}
+
+	return res
+}
+
+// findOpenDraft obtiene un borrador que no haya caducado ni se haya enviado.
+func (s *AnswerService) findOpenDraft(cc *customctx.CustomContext, id string) utils.Response[drafts.DraftModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	draft := s.draftsRepository.Find(cc.Context(), id)
+
+	if draft.Err != nil {
+		entry.Error("Error retrieving draft", draft.Err)
+		return utils.Response[drafts.DraftModel]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(http.StatusNotFound, "Draft not found: "+id, "answers.drafts.not_found"),
+			),
+		}
+	}
+
+	if draft.Data.IsSubmitted() {
+		return utils.Response[drafts.DraftModel]{
+			StatusCode: http.StatusConflict,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusConflict,
+					"Draft was already submitted as answer "+draft.Data.SubmittedAnswerID,
+					"answers.drafts.submitted",
+				),
+			),
+		}
+	}
+
+	if draft.Data.IsSubmitting(time.Now().UTC()) {
+		return utils.Response[drafts.DraftModel]{
+			StatusCode: http.StatusConflict,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(http.StatusConflict, "Draft is being submitted", "answers.drafts.submitting"),
+			),
+		}
+	}
+
+	if draft.Data.IsExpired(time.Now().UTC()) {
+		return utils.Response[drafts.DraftModel]{
+			StatusCode: http.StatusGone,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusGone,
+					"Draft expired at "+draft.Data.ExpiresAt.Format(time.RFC3339),
+					"answers.drafts.expired",
+				),
+			),
+		}
+	}
+
+	return utils.Response[drafts.DraftModel]{
+		Data:       draft.Data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// validateDraftPage valida una página del borrador. Con page se validan todas
+// las preguntas de esa sección; sin page, solo las respuestas enviadas.
+func (s *AnswerService) validateDraftPage(cc *customctx.CustomContext, form forms.FormModel, submitted []answersEntities.AnswerEntity, responses []answersEntities.AnswerEntity, page string) utils.Response[drafts.DraftModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	var scope func(question formsEntities.QuestionEntity) bool
+
+	if page != "" {
+		sections := formsEntities.LayoutSections(form.Questions, form.Sections)
+		if !slices.ContainsFunc(sections, func(section formsEntities.SectionEntity) bool { return section.ID == page }) {
+			return utils.Response[drafts.DraftModel]{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Error: cc.NewError(
+					cerrs.NewCustomError(http.StatusBadRequest, "Unknown page: "+page, "answers.drafts.invalid_page"),
+				),
+			}
+		}
+		scope = func(question formsEntities.QuestionEntity) bool {
+			return question.Section == page
+		}
+	} else {
+		scope = func(question formsEntities.QuestionEntity) bool {
+			return slices.ContainsFunc(submitted, func(answer answersEntities.AnswerEntity) bool {
+				return answer.QuestionID == question.ID
+			})
+		}
+	}
+
+	validation := validatePage(form, submitted, responses, scope)
+
+	if validation.Err != nil {
+		entry.Error("Invalid question configuration", validation.Err)
+		return utils.Response[drafts.DraftModel]{
+			StatusCode: validation.Err.Code,
+			Success:    false,
+			Error:      cc.NewError(validation.Err),
+		}
+	}
+
+	if len(validation.Errors) > 0 {
+		entry.Error("Invalid draft answers", validation.Errors)
+		return utils.Response[drafts.DraftModel]{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusBadRequest,
+					fmt.Sprintf("Invalid answers: %d question(s) with errors", len(validation.Errors)),
+					"answers.drafts.invalid",
+				),
+			),
+			Errors: validation.Errors,
+		}
+	}
+
+	return utils.Response[drafts.DraftModel]{StatusCode: http.StatusOK, Success: true}
+}
+
+// mergeAnswers sustituye en current las respuestas a las mismas preguntas y
+// añade las nuevas, conservando el orden.
+func mergeAnswers(current []answersEntities.AnswerEntity, updates []answersEntities.AnswerEntity) []answersEntities.AnswerEntity {
+
+	merged := slices.Clone(current)
+	if merged == nil {
+		merged = []answersEntities.AnswerEntity{}
+	}
+
+	for _, update := range updates {
+		i := slices.IndexFunc(merged, func(answer answersEntities.AnswerEntity) bool {
+			return answer.QuestionID == update.QuestionID
+		})
+		if i >= 0 {
+			merged[i] = update
+		} else {
+			merged = append(merged, update)
+		}
+	}
+	return merged
+}
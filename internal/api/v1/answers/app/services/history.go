@@ -0,0 +1,72 @@
+package services
+
+import (
+	"common/domain/criteria"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"fomrs/internal/db/mongo/history"
+	"net/http"
+)
+
+// History devuelve los cambios registrados sobre una respuesta, del más
+// antiguo al más reciente.
+func (s *AnswerService) History(cc *customctx.CustomContext, id string) utils.Response[history.AnswerHistoryModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Retrieving history of answer: ", id)
+
+	cri := criteria.Criteria{
+		Filters: *criteria.NewFilters([]criteria.Filter{
+			{
+				Field:    "answer_id",
+				Operator: criteria.OperatorEqual,
+				Value:    id,
+			},
+		}),
+		Orders: []criteria.Order{criteria.NewOrder("created_at", criteria.OrderTypeAsc)},
+	}
+
+	records := s.historyRepository.Matching(cri, "", 0, 0)
+
+	if records.Err != nil {
+		entry.Error("Error retrieving answer history", records.Err)
+		return utils.Response[history.AnswerHistoryModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(records.Err),
+		}
+	}
+
+	return utils.Response[history.AnswerHistoryModel]{
+		Results:    records.Data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// saveHistory añade un registro al historial de la respuesta.
+func (s *AnswerService) saveHistory(cc *customctx.CustomContext, record history.AnswerHistoryModel) utils.Response[history.AnswerHistoryModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	res := s.historyRepository.Save(cc.Context(), record)
+
+	if res.Err != nil {
+		entry.Error("Error saving answer history", res.Err)
+		return utils.Response[history.AnswerHistoryModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(res.Err),
+		}
+	}
+
+	record.ID = res.Data
+
+	return utils.Response[history.AnswerHistoryModel]{
+		Data:       record,
+		StatusCode: http.StatusCreated,
+		Success:    true,
+	}
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"common/domain/criteria"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"context"
+	"fmt"
+	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/db/mongo/forms"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// checkSubmissionPolicy aplica los límites de envío del formulario. Si el
+// formulario admite una respuesta por usuario con latest_wins y el usuario ya
+// respondió, devuelve esa respuesta en Data para sustituirla.
+func (s *AnswerService) checkSubmissionPolicy(cc *customctx.CustomContext, form forms.FormModel, userID string, now time.Time) utils.Response[answers.AnswerModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	policy := form.Settings.Submissions
+
+	if policy.SinglePerUser {
+
+		if userID == "" {
+			return policyError(cc, http.StatusBadRequest, "Form requires user_id to answer", "forms.create.answer.user_required")
+		}
+
+		// Cuentan también las respuestas guardadas antes de activar la política,
+		// que no llevan single_submission y no las cubre el índice único.
+		previous := activeAnswers(form.ID,
+			criteria.Filter{Field: "user_id", Operator: criteria.OperatorEqual, Value: userID},
+		)
+		previous.Orders = []criteria.Order{criteria.NewOrder("created_at", criteria.OrderTypeDesc)}
+
+		latest := s.answersRepository.Matching(previous, "", 0, 1)
+
+		if latest.Err != nil {
+			entry.Error("Error retrieving previous answers", latest.Err)
+			return utils.Response[answers.AnswerModel]{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Error:      cc.NewError(latest.Err),
+			}
+		}
+
+		if len(latest.Data) > 0 {
+
+			if !policy.LatestWins {
+				return policyError(cc, http.StatusConflict, "User already answered this form", "forms.create.answer.already_submitted")
+			}
+
+			// La sustitución no suma respuestas: no aplican los límites.
+			return s.findAnswer(cc, latest.Data[0].ID)
+		}
+	}
+
+	return checkLimits(cc, s.answersRepository, form, now)
+}
+
+// answerCounter cuenta respuestas guardadas; lo cumple AnswersMongoRepository.
+type answerCounter interface {
+	Count(ctx context.Context, cr criteria.Criteria) utils.Result[int64]
+}
+
+// slotReserver lleva la cuenta atómica de respuestas de cada formulario; lo
+// cumple FormCountersMongoRepository.
+type slotReserver interface {
+	Reserve(ctx context.Context, formID string, limit int) utils.Result[bool]
+	Init(ctx context.Context, formID string, total int64) error
+	Release(ctx context.Context, formID string) error
+}
+
+// checkLimits comprueba antes de guardar que la nueva respuesta cabe en
+// max_total y max_per_window. Es una comprobación temprana: el hueco de
+// max_total se reserva después con reserveAnswer y la ventana se revisa con
+// checkWindowRank una vez guardada la respuesta.
+func checkLimits(cc *customctx.CustomContext, counter answerCounter, form forms.FormModel, now time.Time) utils.Response[answers.AnswerModel] {
+
+	policy := form.Settings.Submissions
+
+	if policy.MaxTotal > 0 {
+
+		total := countAnswers(cc, counter, activeAnswers(form.ID))
+
+		if total.Error != nil {
+			return errorResponse(total)
+		}
+
+		if exceedsLimit(total.Data, 1, policy.MaxTotal) {
+			return maxTotalError(cc, policy.MaxTotal)
+		}
+	}
+
+	if policy.MaxPerWindow > 0 {
+
+		recent := countAnswers(cc, counter, windowAnswers(form, now))
+
+		if recent.Error != nil {
+			return errorResponse(recent)
+		}
+
+		if exceedsLimit(recent.Data, 1, policy.MaxPerWindow) {
+			return rateLimitedError(cc, form)
+		}
+	}
+
+	return utils.Response[answers.AnswerModel]{StatusCode: http.StatusOK, Success: true}
+}
+
+// reserveAnswer ocupa un hueco del contador del formulario antes de guardar la
+// respuesta. La reserva es atómica, así que con max_total dos envíos
+// simultáneos no pueden quedarse con el mismo hueco. Los formularios sin
+// contador (anteriores a él) lo crean con sus respuestas activas.
+func reserveAnswer(cc *customctx.CustomContext, slots slotReserver, counter answerCounter, form forms.FormModel) utils.Response[answers.AnswerModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	limit := form.Settings.Submissions.MaxTotal
+
+	reserved := slots.Reserve(cc.Context(), form.ID, limit)
+
+	if reserved.Err != nil && reserved.Err.GetCode() == http.StatusNotFound {
+
+		total := countAnswers(cc, counter, activeAnswers(form.ID))
+
+		if total.Error != nil {
+			return errorResponse(total)
+		}
+
+		if err := slots.Init(cc.Context(), form.ID, total.Data); err != nil {
+			entry.Error("Error creating answers counter", err)
+			return utils.Response[answers.AnswerModel]{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Error:      cc.NewError(cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "forms.create.answer.counter")),
+			}
+		}
+
+		reserved = slots.Reserve(cc.Context(), form.ID, limit)
+	}
+
+	if reserved.Err != nil {
+		entry.Error("Error reserving answer", reserved.Err)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(reserved.Err),
+		}
+	}
+
+	if !reserved.Data {
+		return maxTotalError(cc, limit)
+	}
+
+	return utils.Response[answers.AnswerModel]{StatusCode: http.StatusOK, Success: true}
+}
+
+// releaseAnswer devuelve el hueco de una respuesta que no se guardó o que se
+// eliminó.
+func releaseAnswer(cc *customctx.CustomContext, slots slotReserver, formID string) {
+	if err := slots.Release(cc.Context(), formID); err != nil {
+		logger.FromContext(cc.Context()).Error("Error releasing answer from counter", err)
+	}
+}
+
+// checkWindowRank revisa max_per_window con la respuesta ya guardada. Solo
+// cuenta las respuestas de la ventana con _id menor o igual que el suyo: si
+// dos envíos simultáneos superan el límite se retira el posterior y no ambos.
+func checkWindowRank(cc *customctx.CustomContext, counter answerCounter, form forms.FormModel, now time.Time, answerID string) utils.Response[answers.AnswerModel] {
+
+	policy := form.Settings.Submissions
+
+	if policy.MaxPerWindow <= 0 {
+		return utils.Response[answers.AnswerModel]{StatusCode: http.StatusOK, Success: true}
+	}
+
+	oid, err := primitive.ObjectIDFromHex(answerID)
+	if err != nil {
+		logger.FromContext(cc.Context()).Error("Invalid answer id", err)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "forms.create.answer.invalid_id")),
+		}
+	}
+
+	recent := countAnswers(cc, counter, windowAnswers(form, now,
+		criteria.Filter{Field: "_id", Operator: criteria.OperatorLessEqual, Value: oid},
+	))
+
+	if recent.Error != nil {
+		return errorResponse(recent)
+	}
+
+	if exceedsLimit(recent.Data, 0, policy.MaxPerWindow) {
+		return rateLimitedError(cc, form)
+	}
+
+	return utils.Response[answers.AnswerModel]{StatusCode: http.StatusOK, Success: true}
+}
+
+func countAnswers(cc *customctx.CustomContext, counter answerCounter, cr criteria.Criteria) utils.Response[int64] {
+
+	count := counter.Count(cc.Context(), cr)
+
+	if count.Err != nil {
+		logger.FromContext(cc.Context()).Error("Error counting answers", count.Err)
+		return utils.Response[int64]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(count.Err),
+		}
+	}
+
+	return utils.Response[int64]{StatusCode: http.StatusOK, Success: true, Data: count.Data}
+}
+
+func errorResponse(res utils.Response[int64]) utils.Response[answers.AnswerModel] {
+	return utils.Response[answers.AnswerModel]{
+		StatusCode: res.StatusCode,
+		Success:    false,
+		Error:      res.Error,
+	}
+}
+
+func maxTotalError(cc *customctx.CustomContext, limit int) utils.Response[answers.AnswerModel] {
+	return policyError(cc, http.StatusConflict, fmt.Sprintf("Form reached its limit of %d answers", limit), "forms.create.answer.max_total")
+}
+
+func rateLimitedError(cc *customctx.CustomContext, form forms.FormModel) utils.Response[answers.AnswerModel] {
+	policy := form.Settings.Submissions
+	window := time.Duration(policy.WindowSeconds) * time.Second
+	return policyError(cc, http.StatusTooManyRequests, fmt.Sprintf("Form accepts at most %d answers every %s", policy.MaxPerWindow, window), "forms.create.answer.rate_limited")
+}
+
+// windowAnswers construye el criterio de las respuestas activas del formulario
+// dentro de la ventana de max_per_window más los filtros indicados.
+func windowAnswers(form forms.FormModel, now time.Time, filters ...criteria.Filter) criteria.Criteria {
+	window := time.Duration(form.Settings.Submissions.WindowSeconds) * time.Second
+	return activeAnswers(form.ID, append([]criteria.Filter{
+		{Field: "created_at", Operator: criteria.OperatorGreaterEqual, Value: now.Add(-window)},
+	}, filters...)...)
+}
+
+// exceedsLimit indica si count respuestas guardadas más pending superan el
+// límite; un límite 0 no aplica.
+func exceedsLimit(count int64, pending int64, limit int) bool {
+	return limit > 0 && count+pending > int64(limit)
+}
+
+// activeAnswers construye el criterio de las respuestas no eliminadas del
+// formulario más los filtros indicados.
+func activeAnswers(formID string, filters ...criteria.Filter) criteria.Criteria {
+	return criteria.Criteria{
+		Filters: *criteria.NewFilters(append([]criteria.Filter{
+			{Field: "form_id", Operator: criteria.OperatorEqual, Value: formID},
+			{Field: "deleted_at", Operator: criteria.OperatorExists, Value: false},
+		}, filters...)),
+	}
+}
+
+func policyError(cc *customctx.CustomContext, code int, message string, scope string) utils.Response[answers.AnswerModel] {
+
+	logger.FromContext(cc.Context()).Error(message)
+
+	return utils.Response[answers.AnswerModel]{
+		StatusCode: code,
+		Success:    false,
+		Error:      cc.NewError(cerrs.NewCustomError(code, message, scope)),
+	}
+}
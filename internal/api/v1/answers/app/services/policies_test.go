@@ -0,0 +1,354 @@
+package services
+
+import (
+	"common/domain/criteria"
+	"common/domain/customctx"
+	"common/utils"
+	"common/utils/cerrs"
+	"context"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/db/mongo/forms"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestExceedsLimit(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		count   int64
+		pending int64
+		limit   int
+		want    bool
+	}{
+		{name: "no limit", count: 1000, pending: 1, limit: 0, want: false},
+		{name: "before saving, room left", count: 4, pending: 1, limit: 5, want: false},
+		{name: "before saving, limit reached", count: 5, pending: 1, limit: 5, want: true},
+		{name: "after saving, last one fits", count: 5, pending: 0, limit: 5, want: false},
+		{name: "after saving, concurrent submit over the limit", count: 6, pending: 0, limit: 5, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exceedsLimit(tt.count, tt.pending, tt.limit); got != tt.want {
+				t.Errorf("exceedsLimit(%d, %d, %d) = %v, want %v", tt.count, tt.pending, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+var policyNow = time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeAnswers cuenta en memoria con los filtros que usan las políticas.
+type fakeAnswers []answers.AnswerModel
+
+func (f fakeAnswers) Count(_ context.Context, cr criteria.Criteria) utils.Result[int64] {
+
+	var count int64
+
+	for _, answer := range f {
+		if matchesPolicyFilters(answer, cr.Filters.Get()) {
+			count++
+		}
+	}
+
+	return utils.Result[int64]{Data: count}
+}
+
+func matchesPolicyFilters(answer answers.AnswerModel, filters []criteria.Filter) bool {
+
+	for _, filter := range filters {
+
+		var ok bool
+
+		switch filter.Field {
+		case "form_id":
+			ok = answer.FormID == filter.Value
+		case "user_id":
+			ok = answer.UserID == filter.Value
+		case "deleted_at":
+			ok = answer.IsDeleted() == filter.Value.(bool)
+		case "created_at":
+			ok = !answer.CreatedAt.Before(filter.Value.(time.Time))
+		case "_id":
+			ok = answer.ID <= filter.Value.(primitive.ObjectID).Hex()
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fakeSlots guarda los contadores en memoria con la misma semántica que
+// FormCountersMongoRepository.
+type fakeSlots struct {
+	mu     sync.Mutex
+	totals map[string]int64
+}
+
+func (f *fakeSlots) Reserve(_ context.Context, formID string, limit int) utils.Result[bool] {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	total, ok := f.totals[formID]
+	if !ok {
+		return utils.Result[bool]{Err: cerrs.NewCustomError(http.StatusNotFound, "not found", "counters.reserve.not_found")}
+	}
+
+	if limit > 0 && total >= int64(limit) {
+		return utils.Result[bool]{Data: false}
+	}
+
+	f.totals[formID] = total + 1
+
+	return utils.Result[bool]{Data: true}
+}
+
+func (f *fakeSlots) Init(_ context.Context, formID string, total int64) error {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.totals[formID]; !ok {
+		f.totals[formID] = total
+	}
+
+	return nil
+}
+
+func (f *fakeSlots) Release(_ context.Context, formID string) error {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.totals[formID] > 0 {
+		f.totals[formID]--
+	}
+
+	return nil
+}
+
+func policyForm(policy entities.SubmissionPolicy) forms.FormModel {
+	return forms.FormModel{ID: "form", Settings: entities.FormSettings{Submissions: policy}}
+}
+
+// policyAnswer crea una respuesta cuyo _id sigue el orden de id.
+func policyAnswer(id int, formID string, age time.Duration, deleted bool) answers.AnswerModel {
+
+	answer := answers.AnswerModel{
+		ID:        primitive.ObjectID{11: byte(id)}.Hex(),
+		FormID:    formID,
+		CreatedAt: policyNow.Add(-age),
+	}
+
+	if deleted {
+		deletedAt := policyNow
+		answer.DeletedAt = &deletedAt
+	}
+
+	return answer
+}
+
+func assertPolicy(t *testing.T, res utils.Response[answers.AnswerModel], wantCode int, wantScope string) {
+
+	t.Helper()
+
+	if wantCode == http.StatusOK {
+		if res.Error != nil {
+			t.Fatalf("unexpected error: %v", res.Error)
+		}
+		return
+	}
+
+	if res.Error == nil {
+		t.Fatalf("expected %d %s, got success", wantCode, wantScope)
+	}
+
+	if res.StatusCode != wantCode || res.Error.ToMap()["scope"] != wantScope {
+		t.Errorf("got %d %v, want %d %s", res.StatusCode, res.Error.ToMap()["scope"], wantCode, wantScope)
+	}
+}
+
+func TestCheckLimits(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		policy    entities.SubmissionPolicy
+		saved     fakeAnswers
+		wantCode  int
+		wantScope string
+	}{
+		{
+			name:     "no limits",
+			saved:    fakeAnswers{policyAnswer(1, "form", time.Minute, false)},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "max_total with room left",
+			policy:   entities.SubmissionPolicy{MaxTotal: 2},
+			saved:    fakeAnswers{policyAnswer(1, "form", time.Minute, false)},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "max_total reached",
+			policy: entities.SubmissionPolicy{MaxTotal: 2},
+			saved: fakeAnswers{
+				policyAnswer(1, "form", time.Minute, false),
+				policyAnswer(2, "form", time.Minute, false),
+			},
+			wantCode:  http.StatusConflict,
+			wantScope: "forms.create.answer.max_total",
+		},
+		{
+			name:   "deleted and other forms' answers do not count",
+			policy: entities.SubmissionPolicy{MaxTotal: 2},
+			saved: fakeAnswers{
+				policyAnswer(1, "form", time.Minute, false),
+				policyAnswer(2, "form", time.Minute, true),
+				policyAnswer(3, "other", time.Minute, false),
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "max_per_window reached",
+			policy: entities.SubmissionPolicy{MaxPerWindow: 1, WindowSeconds: 60},
+			saved: fakeAnswers{
+				policyAnswer(1, "form", 30*time.Second, false),
+			},
+			wantCode:  http.StatusTooManyRequests,
+			wantScope: "forms.create.answer.rate_limited",
+		},
+		{
+			name:   "answers outside the window do not count",
+			policy: entities.SubmissionPolicy{MaxPerWindow: 1, WindowSeconds: 60},
+			saved: fakeAnswers{
+				policyAnswer(1, "form", 2*time.Minute, false),
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := customctx.NewCustomContext(context.Background())
+			assertPolicy(t, checkLimits(cc, tt.saved, policyForm(tt.policy), policyNow), tt.wantCode, tt.wantScope)
+		})
+	}
+}
+
+func TestReserveAnswer(t *testing.T) {
+
+	saved := fakeAnswers{
+		policyAnswer(1, "form", time.Minute, false),
+		policyAnswer(2, "form", time.Minute, false),
+		policyAnswer(3, "form", time.Minute, true),
+	}
+
+	t.Run("seeds a missing counter with the active answers", func(t *testing.T) {
+
+		cc := customctx.NewCustomContext(context.Background())
+		slots := &fakeSlots{totals: map[string]int64{}}
+		form := policyForm(entities.SubmissionPolicy{MaxTotal: 3})
+
+		assertPolicy(t, reserveAnswer(cc, slots, saved, form), http.StatusOK, "")
+		assertPolicy(t, reserveAnswer(cc, slots, saved, form), http.StatusConflict, "forms.create.answer.max_total")
+
+		if slots.totals["form"] != 3 {
+			t.Errorf("counter = %d, want 3", slots.totals["form"])
+		}
+	})
+
+	t.Run("a released slot can be reserved again", func(t *testing.T) {
+
+		cc := customctx.NewCustomContext(context.Background())
+		slots := &fakeSlots{totals: map[string]int64{"form": 3}}
+		form := policyForm(entities.SubmissionPolicy{MaxTotal: 3})
+
+		assertPolicy(t, reserveAnswer(cc, slots, saved, form), http.StatusConflict, "forms.create.answer.max_total")
+
+		releaseAnswer(cc, slots, "form")
+
+		assertPolicy(t, reserveAnswer(cc, slots, saved, form), http.StatusOK, "")
+	})
+
+	t.Run("without max_total the counter keeps counting", func(t *testing.T) {
+
+		cc := customctx.NewCustomContext(context.Background())
+		slots := &fakeSlots{totals: map[string]int64{"form": 10}}
+
+		assertPolicy(t, reserveAnswer(cc, slots, saved, policyForm(entities.SubmissionPolicy{})), http.StatusOK, "")
+
+		if slots.totals["form"] != 11 {
+			t.Errorf("counter = %d, want 11", slots.totals["form"])
+		}
+	})
+
+	t.Run("concurrent submits at max_total-1 keep exactly one", func(t *testing.T) {
+
+		slots := &fakeSlots{totals: map[string]int64{}}
+		form := policyForm(entities.SubmissionPolicy{MaxTotal: 3})
+
+		var wg sync.WaitGroup
+		results := make([]utils.Response[answers.AnswerModel], 8)
+
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = reserveAnswer(customctx.NewCustomContext(context.Background()), slots, saved, form)
+			}()
+		}
+
+		wg.Wait()
+
+		accepted := 0
+		for _, res := range results {
+			if res.Error == nil {
+				accepted++
+			}
+		}
+
+		if accepted != 1 {
+			t.Errorf("accepted %d submits, want 1", accepted)
+		}
+	})
+}
+
+func TestCheckWindowRank(t *testing.T) {
+
+	// Dos envíos simultáneos (2 y 3) con un solo hueco en la ventana: ambos
+	// están ya guardados y solo el posterior se retira.
+	saved := fakeAnswers{
+		policyAnswer(1, "form", 30*time.Second, false),
+		policyAnswer(2, "form", time.Second, false),
+		policyAnswer(3, "form", time.Second, false),
+	}
+
+	form := policyForm(entities.SubmissionPolicy{MaxPerWindow: 2, WindowSeconds: 60})
+
+	tests := []struct {
+		name      string
+		answer    answers.AnswerModel
+		wantCode  int
+		wantScope string
+	}{
+		{name: "earlier submit is kept", answer: saved[1], wantCode: http.StatusOK},
+		{name: "later submit is rolled back", answer: saved[2], wantCode: http.StatusTooManyRequests, wantScope: "forms.create.answer.rate_limited"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := customctx.NewCustomContext(context.Background())
+			assertPolicy(t, checkWindowRank(cc, saved, form, policyNow, tt.answer.ID), tt.wantCode, tt.wantScope)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"common/domain/customctx"
 	"common/domain/logger"
 	"common/utils"
+	"common/utils/cerrs"
 	"fomrs/internal/db/mongo/answers"
 	"net/http"
 )
@@ -14,6 +15,14 @@ func (s *AnswerService) Retrieve(cc *customctx.CustomContext, id string) utils.R
 
 	entry.Info("Retrieving answer: ", id)
 
+	return s.findAnswer(cc, id)
+}
+
+// findAnswer obtiene una respuesta que no esté eliminada.
+func (s *AnswerService) findAnswer(cc *customctx.CustomContext, id string) utils.Response[answers.AnswerModel] {
+
+	entry := logger.FromContext(cc.Context())
+
 	answer := s.answersRepository.Find(cc.Context(), id)
 
 	if answer.Err != nil {
@@ -25,6 +34,17 @@ func (s *AnswerService) Retrieve(cc *customctx.CustomContext, id string) utils.R
 		}
 	}
 
+	if answer.Data.IsDeleted() {
+		entry.Error("Answer was deleted")
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(http.StatusNotFound, "Answer was deleted: "+id, "answers.retrieve.deleted"),
+			),
+		}
+	}
+
 	return utils.Response[answers.AnswerModel]{
 		StatusCode: http.StatusOK,
 		Success:    true,
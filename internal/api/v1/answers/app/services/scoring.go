@@ -0,0 +1,167 @@
+package services
+
+import (
+	"fomrs/internal/api/v1/answers/domain/entities"
+	formsEntities "fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/db/mongo/forms"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	utils_internal "fomrs/internal/utils"
+)
+
+// scoreAnswers puntúa las respuestas si el formulario es un quiz. Las
+// preguntas ocultas por sus condiciones no cuentan para la nota máxima.
+func scoreAnswers(form forms.FormModel, responses []entities.AnswerEntity) *entities.ScoreEntity {
+
+	quiz := form.Settings.Quiz
+
+	if !quiz.Enabled {
+		return nil
+	}
+
+	byQuestion := make(map[string]entities.AnswerEntity, len(responses))
+	for _, response := range responses {
+		byQuestion[response.QuestionID] = response
+	}
+
+	visible := formsEntities.Visibility(form.Questions, form.Sections, answeredValues(byQuestion))
+
+	score := &entities.ScoreEntity{Questions: []entities.QuestionScore{}}
+
+	for _, question := range form.Questions {
+
+		if question.Scoring == nil || !visible[question.ID] {
+			continue
+		}
+
+		result := scoreQuestion(question, byQuestion[question.ID])
+
+		score.Points += result.Points
+		score.MaxPoints += result.MaxPoints
+		score.Questions = append(score.Questions, result)
+	}
+
+	score.Points = roundScore(score.Points)
+
+	if score.MaxPoints > 0 {
+		score.Percentage = roundScore(score.Points / score.MaxPoints * 100)
+	}
+
+	score.Passed = score.Percentage >= quiz.PassThreshold
+
+	return score
+}
+
+func scoreQuestion(question formsEntities.QuestionEntity, response entities.AnswerEntity) entities.QuestionScore {
+
+	scoring := question.Scoring
+	questionType := utils_internal.QuestionType(question.Type)
+
+	result := entities.QuestionScore{
+		QuestionID: question.ID,
+		MaxPoints:  scoring.Points,
+	}
+
+	if !isAnswered(response) {
+		return result
+	}
+
+	if !utils_internal.IsMultiValue(questionType) && !utils_internal.IsMatrix(questionType) {
+		// La respuesta ya llega normalizada; las correctas de los formularios
+		// guardados antes de normalizarlas se normalizan aquí.
+		correctAnswers := scoring.CorrectAnswers
+		if validator, err := utils_internal.NewValidator(questionType, question.Metadata); err == nil {
+			correctAnswers = utils_internal.NormalizeValues(validator, correctAnswers)
+		}
+		result.Correct = slices.ContainsFunc(correctAnswers, func(correct string) bool {
+			return sameAnswer(response.Answer, correct)
+		})
+		if result.Correct {
+			result.Points = scoring.Points
+		}
+		return result
+	}
+
+	selected := response.Values
+	if utils_internal.IsMatrix(questionType) {
+		selected = matrixValues(response.Matrix)
+	} else if len(selected) == 0 {
+		// Formato antiguo de checkbox: opciones separadas por comas.
+		for _, value := range strings.Split(response.Answer, ",") {
+			selected = append(selected, strings.TrimSpace(value))
+		}
+	}
+
+	hits, misses := 0, 0
+	seen := make(map[string]bool, len(selected))
+	for _, value := range selected {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		if slices.Contains(scoring.CorrectAnswers, value) {
+			hits++
+		} else {
+			misses++
+		}
+	}
+
+	result.Correct = hits == len(scoring.CorrectAnswers) && misses == 0
+
+	switch {
+	case result.Correct:
+		result.Points = scoring.Points
+	case scoring.PartialCredit:
+		credit := float64(hits-misses) / float64(len(scoring.CorrectAnswers))
+		result.Points = roundScore(scoring.Points * math.Max(0, credit))
+	}
+
+	return result
+}
+
+// sameAnswer compara sin distinguir mayúsculas ni espacios y, si ambos son
+// números, por su valor ("7" y "7.0" son la misma respuesta).
+func sameAnswer(given string, correct string) bool {
+
+	given, correct = strings.TrimSpace(given), strings.TrimSpace(correct)
+
+	a, errA := strconv.ParseFloat(given, 64)
+	b, errB := strconv.ParseFloat(correct, 64)
+	if errA == nil && errB == nil {
+		return a == b
+	}
+
+	return strings.EqualFold(given, correct)
+}
+
+func roundScore(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// revealAnswers añade las respuestas correctas a la nota si el quiz lo permite.
+func revealAnswers(form forms.FormModel, answer answers.AnswerModel) answers.AnswerModel {
+
+	if answer.Score == nil || !form.Settings.Quiz.RevealAnswers {
+		return answer
+	}
+
+	correct := make(map[string][]string, len(form.Questions))
+	for _, question := range form.Questions {
+		if question.Scoring != nil {
+			correct[question.ID] = question.Scoring.CorrectAnswers
+		}
+	}
+
+	score := *answer.Score
+	score.Questions = slices.Clone(score.Questions)
+	for i, question := range score.Questions {
+		score.Questions[i].CorrectAnswers = correct[question.QuestionID]
+	}
+
+	answer.Score = &score
+	return answer
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"fomrs/internal/api/v1/answers/domain/entities"
+	formsEntities "fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/forms"
+	"testing"
+)
+
+func TestSameAnswer(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		given   string
+		correct string
+		want    bool
+	}{
+		{name: "equal", given: "Madrid", correct: "Madrid", want: true},
+		{name: "case insensitive", given: "madrid", correct: "MADRID", want: true},
+		{name: "surrounding spaces", given: "  Madrid ", correct: "Madrid", want: true},
+		{name: "different text", given: "Madrid", correct: "Barcelona", want: false},
+		{name: "numbers by value", given: "7", correct: "7.0", want: true},
+		{name: "numbers with spaces", given: " 7 ", correct: "7", want: true},
+		{name: "different numbers", given: "7", correct: "7.01", want: false},
+		{name: "number and text", given: "7", correct: "siete", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameAnswer(tt.given, tt.correct); got != tt.want {
+				t.Errorf("sameAnswer(%q, %q) = %v, want %v", tt.given, tt.correct, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreQuestion(t *testing.T) {
+
+	options := map[string]any{"options": []any{"a", "b", "c", "d"}}
+	matrix := map[string]any{"rows": []any{"r1", "r2"}, "columns": []any{"x", "y"}}
+
+	tests := []struct {
+		name        string
+		question    formsEntities.QuestionEntity
+		response    entities.AnswerEntity
+		wantCorrect bool
+		wantPoints  float64
+	}{
+		{
+			name:        "single value correct",
+			question:    scoredQuestion("radio", options, 2, false, "b"),
+			response:    entities.AnswerEntity{Answer: "b"},
+			wantCorrect: true,
+			wantPoints:  2,
+		},
+		{
+			name:       "single value wrong",
+			question:   scoredQuestion("radio", options, 2, false, "b"),
+			response:   entities.AnswerEntity{Answer: "c"},
+			wantPoints: 0,
+		},
+		{
+			name:       "unanswered",
+			question:   scoredQuestion("radio", options, 2, false, "b"),
+			response:   entities.AnswerEntity{},
+			wantPoints: 0,
+		},
+		{
+			name:        "any of several correct answers",
+			question:    scoredQuestion("text", nil, 1, false, "Madrid", "Madriz"),
+			response:    entities.AnswerEntity{Answer: "madriz"},
+			wantCorrect: true,
+			wantPoints:  1,
+		},
+		{
+			name:        "number compared by value",
+			question:    scoredQuestion("number", nil, 1, false, "3.50"),
+			response:    entities.AnswerEntity{Answer: "3.5"},
+			wantCorrect: true,
+			wantPoints:  1,
+		},
+		{
+			name:        "correct answer normalized like the response",
+			question:    scoredQuestion("date", map[string]any{"format": "DD/MM/YYYY"}, 1, false, "24/05/2013"),
+			response:    entities.AnswerEntity{Answer: "2013-05-24"},
+			wantCorrect: true,
+			wantPoints:  1,
+		},
+		{
+			name:        "multi value exact",
+			question:    scoredQuestion("checkbox", options, 4, false, "a", "b"),
+			response:    entities.AnswerEntity{Values: []string{"b", "a"}},
+			wantCorrect: true,
+			wantPoints:  4,
+		},
+		{
+			name:       "multi value missing one without partial credit",
+			question:   scoredQuestion("checkbox", options, 4, false, "a", "b"),
+			response:   entities.AnswerEntity{Values: []string{"a"}},
+			wantPoints: 0,
+		},
+		{
+			name:       "partial credit per hit",
+			question:   scoredQuestion("checkbox", options, 4, true, "a", "b"),
+			response:   entities.AnswerEntity{Values: []string{"a"}},
+			wantPoints: 2,
+		},
+		{
+			name:       "partial credit subtracts misses",
+			question:   scoredQuestion("checkbox", options, 3, true, "a", "b", "c"),
+			response:   entities.AnswerEntity{Values: []string{"a", "b", "d"}},
+			wantPoints: 1,
+		},
+		{
+			name:       "partial credit never negative",
+			question:   scoredQuestion("checkbox", options, 4, true, "a"),
+			response:   entities.AnswerEntity{Values: []string{"b", "c"}},
+			wantPoints: 0,
+		},
+		{
+			name:       "repeated values count once",
+			question:   scoredQuestion("checkbox", options, 4, true, "a", "b"),
+			response:   entities.AnswerEntity{Values: []string{"a", "a"}},
+			wantPoints: 2,
+		},
+		{
+			name:        "legacy comma separated checkbox",
+			question:    scoredQuestion("checkbox", options, 2, false, "a", "c"),
+			response:    entities.AnswerEntity{Answer: "a, c"},
+			wantCorrect: true,
+			wantPoints:  2,
+		},
+		{
+			name:     "matrix cells",
+			question: scoredQuestion("matrix", matrix, 2, false, "r1:x", "r2:y"),
+			response: entities.AnswerEntity{Matrix: []entities.MatrixAnswer{
+				{Row: "r1", Columns: []string{"x"}},
+				{Row: "r2", Columns: []string{"y"}},
+			}},
+			wantCorrect: true,
+			wantPoints:  2,
+		},
+		{
+			name:     "matrix partial credit rounded",
+			question: scoredQuestion("matrix", matrix, 1, true, "r1:x", "r2:y", "r2:x"),
+			response: entities.AnswerEntity{Matrix: []entities.MatrixAnswer{
+				{Row: "r1", Columns: []string{"x"}},
+			}},
+			wantPoints: 0.33,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			tt.response.QuestionID = tt.question.ID
+
+			got := scoreQuestion(tt.question, tt.response)
+
+			if got.Correct != tt.wantCorrect || got.Points != tt.wantPoints {
+				t.Errorf("scoreQuestion() = correct %v, points %v; want correct %v, points %v",
+					got.Correct, got.Points, tt.wantCorrect, tt.wantPoints)
+			}
+			if got.MaxPoints != tt.question.Scoring.Points {
+				t.Errorf("scoreQuestion() max points = %v, want %v", got.MaxPoints, tt.question.Scoring.Points)
+			}
+		})
+	}
+}
+
+func TestScoreAnswers(t *testing.T) {
+
+	options := map[string]any{"options": []any{"si", "no"}}
+
+	form := forms.FormModel{
+		Questions: []formsEntities.QuestionEntity{
+			scoredQuestion("radio", options, 1, false, "si"),
+			{ID: "q2", Title: "q2", Type: "text", Scoring: &formsEntities.QuestionScoring{CorrectAnswers: []string{"b"}, Points: 1}},
+			// Solo visible si q1 es "no": no cuenta para el máximo.
+			{
+				ID: "q3", Title: "q3", Type: "text",
+				VisibleIf: &formsEntities.Condition{QuestionID: "q1", Operator: formsEntities.ConditionEquals, Value: "no"},
+				Scoring:   &formsEntities.QuestionScoring{CorrectAnswers: []string{"c"}, Points: 2},
+			},
+		},
+		Settings: formsEntities.FormSettings{Quiz: formsEntities.QuizSettings{Enabled: true, PassThreshold: 50}},
+	}
+
+	tests := []struct {
+		name       string
+		quiz       bool
+		responses  []entities.AnswerEntity
+		wantNil    bool
+		wantPoints float64
+		wantMax    float64
+		wantPct    float64
+		wantPassed bool
+	}{
+		{
+			name:    "quiz disabled",
+			quiz:    false,
+			wantNil: true,
+		},
+		{
+			name: "hidden question excluded",
+			quiz: true,
+			responses: []entities.AnswerEntity{
+				{QuestionID: "q1", Answer: "si"},
+				{QuestionID: "q2", Answer: "x"},
+			},
+			wantPoints: 1,
+			wantMax:    2,
+			wantPct:    50,
+			wantPassed: true,
+		},
+		{
+			name: "visible question counted",
+			quiz: true,
+			responses: []entities.AnswerEntity{
+				{QuestionID: "q1", Answer: "no"},
+				{QuestionID: "q2", Answer: "b"},
+			},
+			wantPoints: 1,
+			wantMax:    4,
+			wantPct:    25,
+			wantPassed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			form.Settings.Quiz.Enabled = tt.quiz
+
+			got := scoreAnswers(form, tt.responses)
+
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("scoreAnswers() = %+v, want nil", got)
+				}
+				return
+			}
+
+			if got == nil {
+				t.Fatal("scoreAnswers() = nil")
+			}
+			if got.Points != tt.wantPoints || got.MaxPoints != tt.wantMax || got.Percentage != tt.wantPct || got.Passed != tt.wantPassed {
+				t.Errorf("scoreAnswers() = %v/%v (%v%%, passed %v), want %v/%v (%v%%, passed %v)",
+					got.Points, got.MaxPoints, got.Percentage, got.Passed,
+					tt.wantPoints, tt.wantMax, tt.wantPct, tt.wantPassed)
+			}
+		})
+	}
+}
+
+func scoredQuestion(questionType string, metadata map[string]any, points float64, partial bool, correct ...string) formsEntities.QuestionEntity {
+	return formsEntities.QuestionEntity{
+		ID:       "q1",
+		Title:    "q1",
+		Type:     questionType,
+		Metadata: metadata,
+		Scoring: &formsEntities.QuestionScoring{
+			CorrectAnswers: correct,
+			Points:         points,
+			PartialCredit:  partial,
+		},
+	}
+}
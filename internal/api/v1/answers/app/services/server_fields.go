@@ -0,0 +1,211 @@
+package services
+
+import (
+	"common/utils/cerrs"
+	"fomrs/internal/api/v1/answers/domain/entities"
+	formsEntities "fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/forms"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	utils_internal "fomrs/internal/utils"
+)
+
+// populateServerFields sustituye los campos hidden y computed por los valores
+// que calcula el servidor: los hidden salen de los parámetros de la URL o del
+// contexto de la petición y los computed de su expresión.
+func populateServerFields(form forms.FormModel, responses []entities.AnswerEntity, query map[string]string, context map[string]string) ([]entities.AnswerEntity, ValidationResult) {
+
+	var result ValidationResult
+
+	populated := withoutServerFields(form, responses)
+
+	for _, question := range form.Questions {
+
+		if utils_internal.QuestionType(question.Type) != utils_internal.QuestionTypeHidden {
+			continue
+		}
+
+		field, err := utils_internal.ParseHiddenField(question.Metadata)
+		if err != nil {
+			result.Err = invalidServerField(question, err)
+			return nil, result
+		}
+
+		value, ok := field.Value(query, context)
+
+		if !ok {
+			if question.Required {
+				result.Errors = append(result.Errors, entities.NewQuestionError(
+					question.ID,
+					question.Title,
+					"forms.create.answer.missing_hidden",
+					"Missing "+field.Source+" value "+field.Param+" for question: "+question.Title,
+				))
+			}
+			continue
+		}
+
+		populated = append(populated, entities.AnswerEntity{QuestionID: question.ID, Answer: value})
+	}
+
+	populated, computed := computeFields(form, populated)
+	computed.Errors = append(result.Errors, computed.Errors...)
+
+	return populated, computed
+}
+
+// computeFields recalcula los campos computed a partir del resto de
+// respuestas, en orden de dependencias, y devuelve responses con los valores
+// calculados en lugar de los anteriores.
+func computeFields(form forms.FormModel, responses []entities.AnswerEntity) ([]entities.AnswerEntity, ValidationResult) {
+
+	var result ValidationResult
+
+	byQuestion := make(map[string]entities.AnswerEntity, len(responses))
+	for _, response := range responses {
+		byQuestion[response.QuestionID] = response
+	}
+
+	questions := make(map[string]formsEntities.QuestionEntity)
+	fields := make(map[string]utils_internal.ComputedField)
+
+	for _, question := range form.Questions {
+
+		if utils_internal.QuestionType(question.Type) != utils_internal.QuestionTypeComputed {
+			continue
+		}
+
+		field, err := utils_internal.ParseComputedField(question.Metadata)
+		if err != nil {
+			result.Err = invalidServerField(question, err)
+			return nil, result
+		}
+
+		questions[question.ID] = question
+		fields[question.ID] = field
+
+		// El valor anterior, si lo hay, no cuenta: se vuelve a calcular.
+		delete(byQuestion, question.ID)
+	}
+
+	// Los ciclos se rechazan al crear el formulario; evaluated evita repetir
+	// trabajo y, por seguridad, cortar un ciclo si llegara a existir.
+	evaluated := make(map[string]bool, len(fields))
+
+	var evaluate func(id string)
+	evaluate = func(id string) {
+
+		if evaluated[id] {
+			return
+		}
+		evaluated[id] = true
+
+		field := fields[id]
+		question := questions[id]
+
+		vars := make(map[string]any, len(field.Variables))
+		for name, questionID := range field.Variables {
+			if _, ok := fields[questionID]; ok {
+				evaluate(questionID)
+			}
+			vars[name] = expressionValue(byQuestion[questionID])
+		}
+
+		value, err := field.Expression.Evaluate(vars)
+
+		answer, ok := utils_internal.FormatValue(value)
+
+		if err != nil || !ok {
+			if question.Required {
+				message := "Computed value is empty"
+				if err != nil {
+					message = err.Error()
+				}
+				result.Errors = append(result.Errors, entities.NewQuestionError(
+					question.ID,
+					question.Title,
+					"forms.create.answer.computed",
+					"Could not compute question: ["+question.Title+"] "+message,
+				))
+			}
+			return
+		}
+
+		byQuestion[id] = entities.AnswerEntity{QuestionID: id, Answer: answer}
+	}
+
+	for _, question := range form.Questions {
+		if _, ok := fields[question.ID]; ok {
+			evaluate(question.ID)
+		}
+	}
+
+	computed := slices.DeleteFunc(slices.Clone(responses), func(response entities.AnswerEntity) bool {
+		_, ok := fields[response.QuestionID]
+		return ok
+	})
+
+	for _, question := range form.Questions {
+		if _, ok := fields[question.ID]; !ok {
+			continue
+		}
+		if response, ok := byQuestion[question.ID]; ok {
+			computed = append(computed, response)
+		}
+	}
+
+	return computed, result
+}
+
+// expressionValue convierte una respuesta en un valor de la expresión: número,
+// booleano o texto; en preguntas de varios valores o matrices, el número de
+// elementos marcados. Sin respuesta vale nil.
+func expressionValue(response entities.AnswerEntity) any {
+
+	switch {
+	case len(response.Values) > 0:
+		return float64(len(response.Values))
+	case len(response.Matrix) > 0:
+		return float64(len(response.Matrix))
+	}
+
+	answer := strings.TrimSpace(response.Answer)
+	if answer == "" {
+		return nil
+	}
+
+	if number, err := strconv.ParseFloat(answer, 64); err == nil {
+		return number
+	}
+
+	if value, err := strconv.ParseBool(answer); err == nil && (answer == "true" || answer == "false") {
+		return value
+	}
+
+	return response.Answer
+}
+
+// withoutServerFields descarta lo que el cliente haya enviado para preguntas
+// que rellena el servidor.
+func withoutServerFields(form forms.FormModel, responses []entities.AnswerEntity) []entities.AnswerEntity {
+	return slices.DeleteFunc(slices.Clone(responses), func(response entities.AnswerEntity) bool {
+		return isServerField(form, response.QuestionID)
+	})
+}
+
+func isServerField(form forms.FormModel, questionID string) bool {
+	return slices.ContainsFunc(form.Questions, func(question formsEntities.QuestionEntity) bool {
+		return question.ID == questionID && utils_internal.IsServerSide(utils_internal.QuestionType(question.Type))
+	})
+}
+
+func invalidServerField(question formsEntities.QuestionEntity, err error) *cerrs.CustomError {
+	return cerrs.NewCustomError(
+		http.StatusInternalServerError,
+		"Invalid configuration for question: ["+question.Title+"] "+err.Error(),
+		"forms.create.answer.invalid_rules",
+	)
+}
@@ -2,14 +2,34 @@ package services
 
 import (
 	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/db/mongo/counters"
+	"fomrs/internal/db/mongo/drafts"
 	"fomrs/internal/db/mongo/forms"
+	"fomrs/internal/db/mongo/history"
+	"fomrs/internal/storage"
+	"time"
 )
 
 type AnswerService struct {
-	formsRepository   *forms.FormsMongoRepository
-	answersRepository *answers.AnswersMongoRepository
+	formsRepository    *forms.FormsMongoRepository
+	answersRepository  *answers.AnswersMongoRepository
+	countersRepository *counters.FormCountersMongoRepository
+	draftsRepository   *drafts.DraftsMongoRepository
+	historyRepository  *history.AnswerHistoryMongoRepository
+	draftTTL           time.Duration
+	storage            storage.Storage
+	uploadMaxSize      int64
 }
 
-func NewAnswerService(formsRepository *forms.FormsMongoRepository, answersRepository *answers.AnswersMongoRepository) *AnswerService {
-	return &AnswerService{formsRepository: formsRepository, answersRepository: answersRepository}
+func NewAnswerService(formsRepository *forms.FormsMongoRepository, answersRepository *answers.AnswersMongoRepository, countersRepository *counters.FormCountersMongoRepository, draftsRepository *drafts.DraftsMongoRepository, historyRepository *history.AnswerHistoryMongoRepository, draftTTL time.Duration, fileStorage storage.Storage, uploadMaxSize int64) *AnswerService {
+	return &AnswerService{
+		formsRepository:    formsRepository,
+		answersRepository:  answersRepository,
+		countersRepository: countersRepository,
+		draftsRepository:   draftsRepository,
+		historyRepository:  historyRepository,
+		draftTTL:           draftTTL,
+		storage:            fileStorage,
+		uploadMaxSize:      uploadMaxSize,
+	}
 }
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"common/domain/criteria"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"fmt"
+	"fomrs/internal/api/v1/answers/domain/commands"
+	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/db/mongo/forms"
+	"fomrs/internal/db/mongo/history"
+	"net/http"
+	"time"
+)
+
+// Update corrige una respuesta enviada. Las respuestas se fusionan con las
+// existentes, el resultado se valida de nuevo contra la versión vigente del
+// formulario y el cambio queda registrado en el historial.
+func (s *AnswerService) Update(cc *customctx.CustomContext, id string, command commands.UpdateAnswerCommand) utils.Response[answers.AnswerModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Updating answer: ", id)
+
+	answer, form := s.findEditableAnswer(cc, id)
+
+	if answer.Error != nil {
+		return answer
+	}
+
+	merged := mergeAnswers(answer.Data.Answers, withoutServerFields(form, command.Responses))
+
+	validation := validatePage(form, command.Responses, merged, nil)
+
+	if validation.Err != nil {
+		entry.Error("Invalid question configuration", validation.Err)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: validation.Err.Code,
+			Success:    false,
+			Error:      cc.NewError(validation.Err),
+		}
+	}
+
+	if len(validation.Errors) > 0 {
+		entry.Error("Invalid answers", validation.Errors)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusBadRequest,
+					fmt.Sprintf("Invalid answers: %d question(s) with errors", len(validation.Errors)),
+					"answers.update.invalid",
+				),
+			),
+			Errors: validation.Errors,
+		}
+	}
+
+	validation.Normalize(merged)
+
+	// Los computed se recalculan con las respuestas corregidas; los hidden se
+	// conservan tal como se guardaron.
+	merged, computed := computeFields(form, merged)
+
+	if computed.Err != nil {
+		entry.Error("Invalid computed field configuration", computed.Err)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: computed.Err.Code,
+			Success:    false,
+			Error:      cc.NewError(computed.Err),
+		}
+	}
+
+	if len(computed.Errors) > 0 {
+		entry.Error("Invalid computed fields", computed.Errors)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusBadRequest,
+					fmt.Sprintf("Invalid answers: %d question(s) with errors", len(computed.Errors)),
+					"answers.update.invalid",
+				),
+			),
+			Errors: computed.Errors,
+		}
+	}
+
+	now := time.Now().UTC()
+
+	// La respuesta pudo eliminarse mientras se validaba: solo se corrige si
+	// sigue activa.
+	updated := s.answersRepository.UpdateFieldsIf(cc.Context(), id, criteria.Where("deleted_at", criteria.OperatorExists, false), map[string]interface{}{
+		"answers":      merged,
+		"score":        scoreAnswers(form, merged),
+		"form_version": form.CurrentVersion(),
+		"updated_at":   now,
+	})
+
+	if updated.Err != nil && updated.Err.GetCode() == http.StatusConflict {
+		entry.Error("Answer was deleted")
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(http.StatusNotFound, "Answer was deleted: "+id, "answers.retrieve.deleted"),
+			),
+		}
+	}
+
+	if updated.Err != nil {
+		entry.Error("Error updating answer", updated.Err)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(updated.Err),
+		}
+	}
+
+	if res := s.saveHistory(cc, history.AnswerHistoryModel{
+		AnswerID:  id,
+		FormID:    answer.Data.FormID,
+		Action:    history.AnswerActionUpdate,
+		Actor:     actorOrOwner(command.Actor, answer.Data),
+		ClientIP:  command.ClientIP,
+		Before:    answer.Data.Answers,
+		After:     merged,
+		CreatedAt: now,
+	}); res.Error != nil {
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: res.StatusCode,
+			Success:    false,
+			Error:      res.Error,
+		}
+	}
+
+	return utils.Response[answers.AnswerModel]{
+		Data:       revealAnswers(form, updated.Data),
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// findEditableAnswer obtiene la respuesta y su formulario comprobando que
+// exista, no esté eliminada y que el formulario permita editar respuestas.
+func (s *AnswerService) findEditableAnswer(cc *customctx.CustomContext, id string) (utils.Response[answers.AnswerModel], forms.FormModel) {
+
+	entry := logger.FromContext(cc.Context())
+
+	answer := s.findAnswer(cc, id)
+
+	if answer.Error != nil {
+		return answer, forms.FormModel{}
+	}
+
+	form := s.findFormAcceptingAnswers(cc, answer.Data.FormID)
+
+	if form.Error != nil {
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: form.StatusCode,
+			Success:    false,
+			Error:      form.Error,
+		}, forms.FormModel{}
+	}
+
+	if !form.Data.Settings.AllowEdits {
+		entry.Error("Form does not allow editing answers")
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusForbidden,
+					"Form does not allow editing answers",
+					"answers.update.not_allowed",
+				),
+			),
+		}, forms.FormModel{}
+	}
+
+	return answer, form.Data
+}
+
+// actorOrOwner devuelve quién realiza el cambio; sin usuario autenticado se
+// atribuye al autor de la respuesta.
+func actorOrOwner(actor string, answer answers.AnswerModel) string {
+	if actor != "" {
+		return actor
+	}
+	return answer.UserID
+}
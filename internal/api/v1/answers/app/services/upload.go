@@ -0,0 +1,371 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"errors"
+	"fmt"
+	"fomrs/internal/api/v1/answers/domain/commands"
+	"fomrs/internal/api/v1/answers/domain/entities"
+	formsEntities "fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/storage"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+
+	utils_internal "fomrs/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// sniffLength es lo que http.DetectContentType necesita para detectar el tipo.
+const sniffLength = 512
+
+// uploadOverhead es el margen para el payload y las cabeceras multipart.
+const uploadOverhead = 1 << 20
+
+// FileDownload es un archivo listo para enviarse al cliente. Quien lo recibe
+// debe cerrar Body.
+type FileDownload struct {
+	File entities.FileReference
+	Body io.ReadCloser
+}
+
+// pendingUpload es un archivo ya validado a la espera de subirse.
+type pendingUpload struct {
+	question    formsEntities.QuestionEntity
+	header      *multipart.FileHeader
+	contentType string
+}
+
+// CreateWithFiles valida los archivos contra los límites de su pregunta, los
+// sube al almacenamiento y guarda la respuesta con la referencia a cada uno.
+// Si la respuesta no se puede guardar, los archivos subidos se eliminan.
+func (s *AnswerService) CreateWithFiles(cc *customctx.CustomContext, command *commands.UploadAnswerCommand) utils.Response[answers.AnswerModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	form := s.findFormAcceptingAnswers(cc, command.FormID)
+
+	if form.Error != nil {
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: form.StatusCode,
+			Success:    false,
+			Error:      form.Error,
+		}
+	}
+
+	if limit := s.uploadLimit(form.Data.Questions); command.BodySize > limit {
+		entry.Error("Upload too large for form: ", command.BodySize)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusRequestEntityTooLarge,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusRequestEntityTooLarge,
+					fmt.Sprintf("Request body must not exceed %d bytes", limit),
+					"forms.create.answer.payload_too_large",
+				),
+			),
+		}
+	}
+
+	pending, errs, err := s.checkFiles(form.Data.Questions, command.Files)
+
+	if err != nil {
+		entry.Error("Invalid file question configuration", err)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: err.Code,
+			Success:    false,
+			Error:      cc.NewError(err),
+		}
+	}
+
+	if len(errs) > 0 {
+		entry.Error("Invalid files", errs)
+		return utils.Response[answers.AnswerModel]{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusBadRequest,
+					fmt.Sprintf("Invalid files: %d question(s) with errors", len(errs)),
+					"forms.create.answer.invalid_files",
+				),
+			),
+			Errors: errs,
+		}
+	}
+
+	uploaded := make([]string, 0, len(pending))
+
+	for _, upload := range pending {
+
+		reference, err := s.uploadFile(cc, command.FormID, upload)
+
+		if err != nil {
+			entry.Error("Error uploading file", err)
+			s.deleteFiles(cc, uploaded)
+			return utils.Response[answers.AnswerModel]{
+				StatusCode: http.StatusBadGateway,
+				Success:    false,
+				Error: cc.NewError(
+					cerrs.NewCustomError(http.StatusBadGateway, "Error storing file for question: "+upload.question.ID, "forms.create.answer.storage"),
+				),
+			}
+		}
+
+		uploaded = append(uploaded, reference.Key)
+		command.Responses = withFile(command.Responses, upload.question.ID, reference)
+	}
+
+	response := s.Create(cc, &command.ResponseCommand)
+
+	if response.Error != nil {
+		s.deleteFiles(cc, uploaded)
+	}
+
+	return response
+}
+
+// uploadLimit devuelve el tamaño máximo del cuerpo de una subida: la suma del
+// max_size efectivo de cada pregunta file del formulario más uploadOverhead.
+// Las preguntas con metadata inválida cuentan con el máximo global y las
+// rechaza después checkFiles. El tope global del cuerpo lo aplica el
+// controlador al leerlo.
+func (s *AnswerService) uploadLimit(questions []formsEntities.QuestionEntity) int64 {
+
+	limit := int64(uploadOverhead)
+
+	for _, question := range questions {
+
+		if question.Type != string(utils_internal.QuestionTypeFile) {
+			continue
+		}
+
+		limits, err := utils_internal.ParseFileLimits(question.Metadata)
+		if err != nil {
+			limits = utils_internal.FileLimits{}
+		}
+
+		maxSize := limits.EffectiveMaxSize(s.uploadMaxSize)
+
+		// Sin max_size ni máximo global solo aplica el tope del cuerpo.
+		if maxSize <= 0 {
+			return math.MaxInt64
+		}
+
+		limit += maxSize
+	}
+
+	return limit
+}
+
+// checkFiles comprueba que cada archivo corresponda a una pregunta de tipo file
+// y respete su tamaño máximo y tipos MIME permitidos. El tipo se detecta a
+// partir del contenido, no de la cabecera que envía el cliente.
+func (s *AnswerService) checkFiles(questions []formsEntities.QuestionEntity, files map[string][]*multipart.FileHeader) ([]pendingUpload, []cerrs.CustomErrorInterface, *cerrs.CustomError) {
+
+	byID := make(map[string]formsEntities.QuestionEntity, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+
+	var pending []pendingUpload
+	var errs []cerrs.CustomErrorInterface
+
+	for questionID, headers := range files {
+
+		question, ok := byID[questionID]
+
+		if !ok || question.Type != string(utils_internal.QuestionTypeFile) {
+			errs = append(errs, entities.NewQuestionError(
+				questionID, question.Title, "forms.create.answer.unexpected_file",
+				"Field is not a file question of this form",
+			))
+			continue
+		}
+
+		if len(headers) > 1 {
+			errs = append(errs, entities.NewQuestionError(
+				question.ID, question.Title, "forms.create.answer.too_many_files",
+				"Only one file per question is allowed",
+			))
+			continue
+		}
+
+		limits, err := utils_internal.ParseFileLimits(question.Metadata)
+		if err != nil {
+			return nil, nil, cerrs.NewCustomError(http.StatusInternalServerError, fmt.Sprintf("question %s: %v", question.ID, err), "forms.create.answer.invalid_rules")
+		}
+
+		header := headers[0]
+
+		if maxSize := limits.EffectiveMaxSize(s.uploadMaxSize); maxSize > 0 && header.Size > maxSize {
+			errs = append(errs, entities.NewQuestionError(
+				question.ID, question.Title, "forms.create.answer.file_too_large",
+				fmt.Sprintf("File exceeds the maximum size of %d bytes", maxSize),
+			))
+			continue
+		}
+
+		contentType, err := detectContentType(header)
+		if err != nil {
+			return nil, nil, cerrs.NewCustomError(http.StatusBadRequest, "Error reading file: "+header.Filename, "forms.create.answer.unreadable_file")
+		}
+
+		if !limits.AllowsMimeType(contentType) {
+			errs = append(errs, entities.NewQuestionError(
+				question.ID, question.Title, "forms.create.answer.invalid_mime_type",
+				fmt.Sprintf("File type %s is not allowed (allowed: %s)", contentType, strings.Join(limits.AllowedMimeTypes, ", ")),
+			))
+			continue
+		}
+
+		pending = append(pending, pendingUpload{question: question, header: header, contentType: contentType})
+	}
+
+	return pending, errs, nil
+}
+
+func detectContentType(header *multipart.FileHeader) (string, error) {
+
+	file, err := header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	buffer := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, buffer)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	return http.DetectContentType(buffer[:n]), nil
+}
+
+func (s *AnswerService) uploadFile(cc *customctx.CustomContext, formID string, upload pendingUpload) (entities.FileReference, error) {
+
+	file, err := upload.header.Open()
+	if err != nil {
+		return entities.FileReference{}, err
+	}
+	defer file.Close()
+
+	reference := entities.FileReference{
+		Key:         path.Join("forms", formID, uuid.New().String()+strings.ToLower(path.Ext(upload.header.Filename))),
+		Name:        path.Base(upload.header.Filename),
+		Size:        upload.header.Size,
+		ContentType: upload.contentType,
+	}
+
+	if err := s.storage.Put(cc.Context(), reference.Key, file, reference.Size, reference.ContentType); err != nil {
+		return entities.FileReference{}, err
+	}
+
+	return reference, nil
+}
+
+func (s *AnswerService) deleteFiles(cc *customctx.CustomContext, keys []string) {
+
+	entry := logger.FromContext(cc.Context())
+
+	for _, key := range keys {
+		if err := s.storage.Delete(cc.Context(), key); err != nil {
+			entry.Error("Error deleting orphan file ", key, err)
+		}
+	}
+}
+
+// withFile asocia la referencia a la respuesta de la pregunta (creándola si no
+// venía en el payload). Answer guarda el nombre original para que se sigan
+// aplicando las reglas de extensión del validador.
+func withFile(responses []entities.AnswerEntity, questionID string, reference entities.FileReference) []entities.AnswerEntity {
+
+	for i := range responses {
+		if responses[i].QuestionID == questionID {
+			responses[i].Answer = reference.Name
+			responses[i].Values = nil
+			responses[i].File = &reference
+			return responses
+		}
+	}
+
+	return append(responses, entities.AnswerEntity{
+		QuestionID: questionID,
+		Answer:     reference.Name,
+		File:       &reference,
+	})
+}
+
+// DownloadFile abre el archivo subido para una pregunta de la respuesta.
+func (s *AnswerService) DownloadFile(cc *customctx.CustomContext, id string, questionID string) utils.Response[FileDownload] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Downloading file: ", id, " ", questionID)
+
+	answer := s.findAnswer(cc, id)
+
+	if answer.Error != nil {
+		return utils.Response[FileDownload]{
+			StatusCode: answer.StatusCode,
+			Success:    false,
+			Error:      answer.Error,
+		}
+	}
+
+	var reference *entities.FileReference
+	for _, response := range answer.Data.Answers {
+		if response.QuestionID == questionID && response.File != nil {
+			reference = response.File
+			break
+		}
+	}
+
+	if reference == nil {
+		return utils.Response[FileDownload]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(http.StatusNotFound, "No file for question: "+questionID, "answers.files.not_found"),
+			),
+		}
+	}
+
+	body, err := s.storage.Get(cc.Context(), reference.Key)
+
+	if errors.Is(err, storage.ErrNotFound) {
+		return utils.Response[FileDownload]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(http.StatusNotFound, "File not found in storage: "+questionID, "answers.files.missing"),
+			),
+		}
+	}
+
+	if err != nil {
+		entry.Error("Error reading file", err)
+		return utils.Response[FileDownload]{
+			StatusCode: http.StatusBadGateway,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(http.StatusBadGateway, "Error reading file", "answers.files.storage"),
+			),
+		}
+	}
+
+	return utils.Response[FileDownload]{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Data:       FileDownload{File: *reference, Body: body},
+	}
+}
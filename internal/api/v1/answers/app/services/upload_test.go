@@ -0,0 +1,71 @@
+package services
+
+import (
+	formsEntities "fomrs/internal/api/v1/forms/domain/entities"
+	"math"
+	"testing"
+)
+
+func TestUploadLimit(t *testing.T) {
+
+	file := func(metadata map[string]any) formsEntities.QuestionEntity {
+		return formsEntities.QuestionEntity{Type: "file", Metadata: metadata}
+	}
+
+	tests := []struct {
+		name      string
+		global    int64
+		questions []formsEntities.QuestionEntity
+		want      int64
+	}{
+		{
+			name:      "no file questions",
+			global:    10 << 20,
+			questions: []formsEntities.QuestionEntity{{Type: "text-short"}},
+			want:      uploadOverhead,
+		},
+		{
+			name:      "one file with the global maximum",
+			global:    10 << 20,
+			questions: []formsEntities.QuestionEntity{file(nil)},
+			want:      10<<20 + uploadOverhead,
+		},
+		{
+			name:   "sum of every file question",
+			global: 10 << 20,
+			questions: []formsEntities.QuestionEntity{
+				file(map[string]any{"max_size": 2 << 20}),
+				file(map[string]any{"max_size": 3 << 20}),
+				file(nil),
+			},
+			want: 15<<20 + uploadOverhead,
+		},
+		{
+			name:      "max_size above the global maximum",
+			global:    10 << 20,
+			questions: []formsEntities.QuestionEntity{file(map[string]any{"max_size": 50 << 20})},
+			want:      10<<20 + uploadOverhead,
+		},
+		{
+			name:      "invalid metadata counts as the global maximum",
+			global:    10 << 20,
+			questions: []formsEntities.QuestionEntity{file(map[string]any{"max_size": -1})},
+			want:      10<<20 + uploadOverhead,
+		},
+		{
+			name:      "no maximum at all",
+			global:    0,
+			questions: []formsEntities.QuestionEntity{file(nil)},
+			want:      math.MaxInt64,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &AnswerService{uploadMaxSize: tt.global}
+			if got := s.uploadLimit(tt.questions); got != tt.want {
+				t.Errorf("uploadLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"common/utils/cerrs"
+	answersEntities "fomrs/internal/api/v1/answers/domain/entities"
+	formsEntities "fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/forms"
+	"net/http"
+	"strings"
+
+	utils_internal "fomrs/internal/utils"
+)
+
+// ValidationResult agrupa los errores de validación de todas las preguntas.
+// Err se usa solo para fallos que no son culpa del usuario (configuración de
+// la pregunta inválida).
+type ValidationResult struct {
+	Errors []cerrs.CustomErrorInterface
+	Err    *cerrs.CustomError
+
+	// Normalized guarda la forma canónica de las respuestas que la tienen
+	// (teléfonos, fechas con formato propio...), por question_id.
+	Normalized map[string]string
+}
+
+// Normalize sustituye en responses las respuestas por su forma canónica.
+func (r ValidationResult) Normalize(responses []answersEntities.AnswerEntity) {
+	for i, response := range responses {
+		if normalized, ok := r.Normalized[response.QuestionID]; ok {
+			responses[i].Answer = normalized
+		}
+	}
+}
+
+// validateResponses valida todas las preguntas y acumula un error por pregunta
+// en lugar de cortar en el primero. Salvo que el formulario sea lenient, también
+// rechaza respuestas que no encajan con el esquema del formulario.
+func validateResponses(form forms.FormModel, responses []answersEntities.AnswerEntity) ValidationResult {
+	return validatePage(form, responses, responses, nil)
+}
+
+// validatePage valida solo las preguntas que cumplen scope (todas si es nil).
+// submitted son las entradas recibidas en la petición, a las que se aplica el
+// modo estricto; responses es el conjunto completo de respuestas con el que se
+// calcula la visibilidad.
+func validatePage(form forms.FormModel, submitted []answersEntities.AnswerEntity, responses []answersEntities.AnswerEntity, scope func(question formsEntities.QuestionEntity) bool) ValidationResult {
+
+	var result ValidationResult
+
+	if !form.Settings.Lenient {
+		result.Errors = validateSchema(form, submitted)
+	}
+
+	byQuestion := make(map[string]answersEntities.AnswerEntity, len(responses))
+	for _, response := range responses {
+		byQuestion[response.QuestionID] = response
+	}
+
+	visible := formsEntities.Visibility(form.Questions, form.Sections, answeredValues(byQuestion))
+
+	sent := make(map[string]bool, len(submitted))
+	for _, response := range submitted {
+		sent[response.QuestionID] = true
+	}
+
+	for _, question := range form.Questions {
+
+		if scope != nil && !scope(question) {
+			continue
+		}
+
+		// Los campos hidden y computed los rellena el servidor después.
+		if utils_internal.IsServerSide(utils_internal.QuestionType(question.Type)) {
+			continue
+		}
+
+		response := byQuestion[question.ID]
+
+		// Las preguntas ocultas no se validan ni pueden recibir respuesta.
+		if !visible[question.ID] {
+			if isAnswered(response) {
+				result.Errors = append(result.Errors, answersEntities.NewQuestionError(
+					question.ID,
+					question.Title,
+					"forms.create.answer.hidden_question",
+					"Question is not visible with the given answers: "+question.Title,
+				))
+			}
+			continue
+		}
+
+		questionType := utils_internal.QuestionType(question.Type)
+
+		// Las preguntas file solo se responden subiendo el archivo con
+		// POST /v1/answers/upload; un nombre de archivo suelto no vale. Las
+		// respuestas ya guardadas (al corregir) no se vuelven a comprobar.
+		if questionType == utils_internal.QuestionTypeFile && sent[question.ID] && response.File == nil && isAnswered(response) {
+			result.Errors = append(result.Errors, answersEntities.NewQuestionError(
+				question.ID,
+				question.Title,
+				"forms.create.answer.file_not_uploaded",
+				"File must be uploaded with POST /v1/answers/upload: "+question.Title,
+			))
+			continue
+		}
+
+		multiValue := utils_internal.IsMultiValue(questionType) && len(response.Values) > 0
+		matrix := utils_internal.IsMatrix(questionType) && len(response.Matrix) > 0
+
+		if !multiValue && !matrix && strings.TrimSpace(response.Answer) == "" {
+			if question.Required {
+				result.Errors = append(result.Errors, answersEntities.NewQuestionError(
+					question.ID,
+					question.Title,
+					"forms.create.answer.required",
+					"Question is required: "+question.Title,
+				))
+			}
+			continue
+		}
+
+		validator, err := utils_internal.NewValidator(questionType, question.Metadata)
+		if err != nil {
+			result.Err = cerrs.NewCustomError(
+				http.StatusInternalServerError,
+				"Invalid configuration for question: ["+question.Title+"] "+err.Error(),
+				"forms.create.answer.invalid_rules",
+			)
+			return result
+		}
+
+		var isValid bool
+		if matrix {
+			selections, ok := matrixSelections(response.Matrix)
+			isValid = ok && response.Answer == "" && utils_internal.ValidateMatrix(validator, selections)
+		} else if multiValue {
+			isValid = utils_internal.ValidateValues(validator, response.Values)
+		} else {
+			isValid = validator.IsValid(response.Answer)
+		}
+
+		if !isValid {
+			result.Errors = append(result.Errors, answersEntities.NewQuestionError(
+				question.ID,
+				question.Title,
+				"forms.create.answer.invalid",
+				"Invalid answer: ["+question.Title+"] "+validator.Description(),
+			))
+			continue
+		}
+
+		if !multiValue && !matrix {
+			if normalized := utils_internal.Normalize(validator, response.Answer); normalized != response.Answer {
+				if result.Normalized == nil {
+					result.Normalized = make(map[string]string)
+				}
+				result.Normalized[question.ID] = normalized
+			}
+		}
+	}
+
+	return result
+}
+
+// isAnswered indica si la respuesta trae algún valor.
+func isAnswered(response answersEntities.AnswerEntity) bool {
+	return strings.TrimSpace(response.Answer) != "" || len(response.Values) > 0 || len(response.Matrix) > 0
+}
+
+// matrixSelections agrupa las columnas por fila; una fila repetida invalida la
+// respuesta.
+func matrixSelections(rows []answersEntities.MatrixAnswer) (map[string][]string, bool) {
+
+	selections := make(map[string][]string, len(rows))
+
+	for _, row := range rows {
+		if _, ok := selections[row.Row]; ok {
+			return nil, false
+		}
+		selections[row.Row] = row.Columns
+	}
+	return selections, true
+}
+
+// matrixValues aplana una matriz como "fila:columna" para las condiciones.
+func matrixValues(rows []answersEntities.MatrixAnswer) []string {
+
+	var values []string
+	for _, row := range rows {
+		for _, column := range row.Columns {
+			values = append(values, row.Row+":"+column)
+		}
+	}
+	return values
+}
+
+// answeredValues devuelve los valores respondidos por pregunta para evaluar
+// las condiciones de visibilidad.
+func answeredValues(byQuestion map[string]answersEntities.AnswerEntity) map[string][]string {
+
+	values := make(map[string][]string, len(byQuestion))
+
+	for id, response := range byQuestion {
+		if len(response.Matrix) > 0 {
+			values[id] = matrixValues(response.Matrix)
+		} else if len(response.Values) > 0 {
+			values[id] = response.Values
+		} else if response.Answer != "" {
+			values[id] = []string{response.Answer}
+		}
+	}
+	return values
+}
+
+// validateSchema aplica el modo estricto: cada respuesta debe pertenecer a una
+// pregunta del formulario, aparecer una sola vez y usar values solo en tipos
+// de varios valores (y matrix solo en matrices).
+func validateSchema(form forms.FormModel, responses []answersEntities.AnswerEntity) []cerrs.CustomErrorInterface {
+
+	var errs []cerrs.CustomErrorInterface
+
+	titles := make(map[string]string, len(form.Questions))
+	types := make(map[string]string, len(form.Questions))
+	for _, question := range form.Questions {
+		titles[question.ID] = question.Title
+		types[question.ID] = question.Type
+	}
+
+	seen := make(map[string]bool, len(responses))
+
+	for _, response := range responses {
+
+		title, ok := titles[response.QuestionID]
+
+		if !ok {
+			errs = append(errs, answersEntities.NewQuestionError(
+				response.QuestionID,
+				"",
+				"forms.create.answer.unknown_question",
+				"Question does not belong to the form: "+response.QuestionID,
+			))
+			continue
+		}
+
+		if seen[response.QuestionID] {
+			errs = append(errs, answersEntities.NewQuestionError(
+				response.QuestionID,
+				title,
+				"forms.create.answer.duplicated",
+				"Question answered more than once: "+title,
+			))
+			continue
+		}
+		seen[response.QuestionID] = true
+
+		if utils_internal.IsServerSide(utils_internal.QuestionType(types[response.QuestionID])) {
+			errs = append(errs, answersEntities.NewQuestionError(
+				response.QuestionID,
+				title,
+				"forms.create.answer.read_only",
+				"Question is filled by the server and cannot be answered: "+title,
+			))
+			continue
+		}
+
+		if len(response.Values) > 0 && !utils_internal.IsMultiValue(utils_internal.QuestionType(types[response.QuestionID])) {
+			errs = append(errs, answersEntities.NewQuestionError(
+				response.QuestionID,
+				title,
+				"forms.create.answer.unexpected_values",
+				"Question accepts a single value, use answer instead of values: "+title,
+			))
+		}
+
+		if len(response.Matrix) > 0 && !utils_internal.IsMatrix(utils_internal.QuestionType(types[response.QuestionID])) {
+			errs = append(errs, answersEntities.NewQuestionError(
+				response.QuestionID,
+				title,
+				"forms.create.answer.unexpected_matrix",
+				"Question is not a matrix, use answer instead of matrix: "+title,
+			))
+		}
+	}
+
+	return errs
+}
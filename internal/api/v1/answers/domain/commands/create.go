@@ -6,4 +6,13 @@ type ResponseCommand struct {
 	FormID    string                  `json:"form_id" binding:"required"`
 	UserID    string                  `json:"user_id"`
 	Responses []entities.AnswerEntity `json:"responses" binding:"required"`
+
+	// Datos de la petición, los completa el controlador.
+	ClientIP  string `json:"-"`
+	UserAgent string `json:"-"`
+
+	// Query y Context alimentan los campos hidden: parámetros de la URL y
+	// valores del contexto de la petición (p. ej. el tenant del usuario).
+	Query   map[string]string `json:"-"`
+	Context map[string]string `json:"-"`
 }
@@ -0,0 +1,28 @@
+package commands
+
+import "fomrs/internal/api/v1/answers/domain/entities"
+
+type CreateDraftCommand struct {
+	FormID    string                  `json:"form_id"`
+	UserID    string                  `json:"user_id"`
+	Responses []entities.AnswerEntity `json:"responses"`
+}
+
+// UpdateDraftCommand guarda una página del borrador. Page es el id de la
+// sección que se valida completa (incluidas las obligatorias); si va vacío
+// solo se validan las respuestas enviadas.
+type UpdateDraftCommand struct {
+	Page      string                  `json:"page"`
+	Responses []entities.AnswerEntity `json:"responses"`
+}
+
+type SubmitDraftCommand struct {
+	// Actor es quien envía el borrador: el usuario autenticado o X-User-ID.
+	Actor string `json:"-"`
+
+	// Datos de la petición, los completa el controlador.
+	ClientIP  string            `json:"-"`
+	UserAgent string            `json:"-"`
+	Query     map[string]string `json:"-"`
+	Context   map[string]string `json:"-"`
+}
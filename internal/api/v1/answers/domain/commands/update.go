@@ -0,0 +1,20 @@
+package commands
+
+import "fomrs/internal/api/v1/answers/domain/entities"
+
+// UpdateAnswerCommand corrige una respuesta enviada: las respuestas indicadas
+// sustituyen a las anteriores de la misma pregunta.
+type UpdateAnswerCommand struct {
+	Responses []entities.AnswerEntity `json:"responses"`
+
+	// Datos de la petición, los completa el controlador.
+	Actor     string `json:"-"`
+	ClientIP  string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+type DeleteAnswerCommand struct {
+	// Datos de la petición, los completa el controlador.
+	Actor    string `json:"-"`
+	ClientIP string `json:"-"`
+}
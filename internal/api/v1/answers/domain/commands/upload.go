@@ -0,0 +1,15 @@
+package commands
+
+import "mime/multipart"
+
+// UploadAnswerCommand es una respuesta enviada como multipart: además de las
+// respuestas, incluye un archivo por pregunta de tipo file.
+type UploadAnswerCommand struct {
+	ResponseCommand
+
+	// Files agrupa los archivos recibidos por question_id.
+	Files map[string][]*multipart.FileHeader
+
+	// BodySize son los bytes leídos del cuerpo de la petición.
+	BodySize int64
+}
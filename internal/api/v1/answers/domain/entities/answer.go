@@ -4,6 +4,27 @@ type AnswerEntity struct {
 	QuestionID string   `json:"question_id" binding:"required"`
 	Answer     string   `json:"answer"`
 	Values     []string `json:"values"`
+
+	// Matrix es la respuesta de las preguntas de tipo matrix: las columnas
+	// marcadas en cada fila.
+	Matrix []MatrixAnswer `json:"matrix,omitempty"`
+
+	// File referencia el archivo subido para preguntas de tipo file.
+	File *FileReference `json:"file,omitempty"`
+}
+
+// MatrixAnswer son las columnas marcadas en una fila de una matriz.
+type MatrixAnswer struct {
+	Row     string   `json:"row"`
+	Columns []string `json:"columns"`
+}
+
+// FileReference apunta al objeto guardado en el almacenamiento.
+type FileReference struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
 }
 
 type ResponseEntity struct {
@@ -0,0 +1,39 @@
+package entities
+
+import "net/http"
+
+// QuestionError es el error de validación de una pregunta concreta. Code es el
+// scope estable (p. ej. forms.create.answer.required) para que el front pueda
+// marcar el campo.
+type QuestionError struct {
+	QuestionID string `json:"question_id"`
+	Title      string `json:"title"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func NewQuestionError(questionID string, title string, code string, message string) *QuestionError {
+	return &QuestionError{
+		QuestionID: questionID,
+		Title:      title,
+		Code:       code,
+		Message:    message,
+	}
+}
+
+func (e *QuestionError) Error() string {
+	return e.Message
+}
+
+func (e *QuestionError) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"question_id": e.QuestionID,
+		"title":       e.Title,
+		"code":        e.Code,
+		"message":     e.Message,
+	}
+}
+
+func (e *QuestionError) GetCode() int {
+	return http.StatusBadRequest
+}
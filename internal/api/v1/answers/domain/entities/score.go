@@ -0,0 +1,23 @@
+package entities
+
+// ScoreEntity es la nota de una respuesta a un formulario con quiz.
+type ScoreEntity struct {
+	Points     float64 `json:"points" bson:"points"`
+	MaxPoints  float64 `json:"max_points" bson:"max_points"`
+	Percentage float64 `json:"percentage" bson:"percentage"`
+	Passed     bool    `json:"passed" bson:"passed"`
+
+	Questions []QuestionScore `json:"questions" bson:"questions"`
+}
+
+// QuestionScore es el resultado de una pregunta puntuable.
+type QuestionScore struct {
+	QuestionID string  `json:"question_id" bson:"question_id"`
+	Correct    bool    `json:"correct" bson:"correct"`
+	Points     float64 `json:"points" bson:"points"`
+	MaxPoints  float64 `json:"max_points" bson:"max_points"`
+
+	// CorrectAnswers solo se rellena en la respuesta del envío si el quiz
+	// revela las soluciones; no se guarda.
+	CorrectAnswers []string `json:"correct_answers,omitempty" bson:"-"`
+}
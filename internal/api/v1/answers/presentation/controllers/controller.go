@@ -4,8 +4,12 @@ import "fomrs/internal/api/v1/answers/app/services"
 
 type AnswerController struct {
 	service *services.AnswerService
+
+	// uploadMaxBodySize es el tope global del cuerpo de las subidas de
+	// archivos, sea cual sea el formulario.
+	uploadMaxBodySize int64
 }
 
-func NewAnswerController(service *services.AnswerService) *AnswerController {
-	return &AnswerController{service: service}
+func NewAnswerController(service *services.AnswerService, uploadMaxBodySize int64) *AnswerController {
+	return &AnswerController{service: service, uploadMaxBodySize: uploadMaxBodySize}
 }
@@ -4,6 +4,7 @@ import (
 	"common/domain/customctx"
 	"common/domain/logger"
 	"common/interface/cdtos"
+	"fmt"
 	"fomrs/internal/api/v1/answers/presentation/dtos"
 
 	"github.com/gin-gonic/gin"
@@ -26,9 +27,46 @@ func (c *AnswerController) Create(ctx *gin.Context) {
 	}
 
 	command := dto.Data.ToCommand()
+	command.ClientIP = ctx.ClientIP()
+	command.UserAgent = ctx.Request.UserAgent()
+	command.Query, command.Context = requestParams(ctx, command.UserID)
 
 	response := c.service.Create(cc, &command)
 
 	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
 
 }
+
+// requestParams recoge los valores con los que se rellenan los campos hidden:
+// los parámetros de la URL y las claves del contexto de gin (las que dejan los
+// middlewares, p. ej. tenant_id), más client_ip, user_agent y user_id si el
+// contexto no lo trae.
+func requestParams(ctx *gin.Context, userID string) (map[string]string, map[string]string) {
+
+	query := make(map[string]string)
+	for key, values := range ctx.Request.URL.Query() {
+		if len(values) > 0 {
+			query[key] = values[0]
+		}
+	}
+
+	context := make(map[string]string)
+	for key, value := range ctx.Keys {
+		switch v := value.(type) {
+		case string:
+			context[key] = v
+		case fmt.Stringer:
+			context[key] = v.String()
+		case int, int64, float64, bool:
+			context[key] = fmt.Sprint(v)
+		}
+	}
+
+	if _, ok := context["user_id"]; !ok && userID != "" {
+		context["user_id"] = userID
+	}
+	context["client_ip"] = ctx.ClientIP()
+	context["user_agent"] = ctx.Request.UserAgent()
+
+	return query, context
+}
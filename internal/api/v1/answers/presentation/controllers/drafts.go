@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"fomrs/internal/api/v1/answers/domain/commands"
+	"fomrs/internal/api/v1/answers/presentation/dtos"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *AnswerController) CreateDraft(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	entry.Info("Creating draft")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	dto := cdtos.GetDTOWithResponse[dtos.CreateDraftDTO](ctx, cc)
+
+	if dto.Error != nil {
+		entry.Error("Error getting dto", dto.Error)
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := c.service.CreateDraft(cc, dto.Data.ToCommand())
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *AnswerController) RetrieveDraft(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx)
+
+	id := ctx.Param("id")
+
+	entry.Info("Retrieving draft: ", id)
+
+	if id == "" {
+		entry.Error("id is required")
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+
+	response := c.service.RetrieveDraft(cc, id)
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *AnswerController) UpdateDraft(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	id := ctx.Param("id")
+
+	entry.Info("Updating draft: ", id)
+
+	if id == "" {
+		entry.Error("id is required")
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+
+	dto := cdtos.GetDTOWithResponse[dtos.UpdateDraftDTO](ctx, cc)
+
+	if dto.Error != nil {
+		entry.Error("Error getting dto", dto.Error)
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := c.service.UpdateDraft(cc, id, dto.Data.ToCommand())
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *AnswerController) SubmitDraft(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	id := ctx.Param("id")
+
+	entry.Info("Submitting draft: ", id)
+
+	if id == "" {
+		entry.Error("id is required")
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+
+	query, context := requestParams(ctx, actor(ctx))
+
+	response := c.service.SubmitDraft(cc, id, commands.SubmitDraftCommand{
+		Actor:     actor(ctx),
+		ClientIP:  ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+		Query:     query,
+		Context:   context,
+	})
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"fomrs/internal/api/v1/answers/domain/commands"
+	"fomrs/internal/api/v1/answers/presentation/dtos"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// actor identifica a quien realiza el cambio: el usuario autenticado (clave
+// user_id del contexto de gin) o, en su defecto, la cabecera X-User-ID.
+func actor(ctx *gin.Context) string {
+	if userID := ctx.GetString("user_id"); userID != "" {
+		return userID
+	}
+	return ctx.GetHeader("X-User-ID")
+}
+
+func (c *AnswerController) Update(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	id := ctx.Param("id")
+
+	entry.Info("Updating answer: ", id)
+
+	if id == "" {
+		entry.Error("id is required")
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+
+	dto := cdtos.GetDTOWithResponse[dtos.UpdateAnswerDTO](ctx, cc)
+
+	if dto.Error != nil {
+		entry.Error("Error getting dto", dto.Error)
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	command := dto.Data.ToCommand()
+	command.Actor = actor(ctx)
+	command.ClientIP = ctx.ClientIP()
+	command.UserAgent = ctx.Request.UserAgent()
+
+	response := c.service.Update(cc, id, command)
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *AnswerController) Delete(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	id := ctx.Param("id")
+
+	entry.Info("Deleting answer: ", id)
+
+	if id == "" {
+		entry.Error("id is required")
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+
+	response := c.service.Delete(cc, id, commands.DeleteAnswerCommand{
+		Actor:    actor(ctx),
+		ClientIP: ctx.ClientIP(),
+	})
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *AnswerController) History(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	id := ctx.Param("id")
+
+	entry.Info("Retrieving answer history: ", id)
+
+	if id == "" {
+		entry.Error("id is required")
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+
+	response := c.service.History(cc, id)
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
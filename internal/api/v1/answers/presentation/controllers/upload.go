@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"common/utils"
+	"common/utils/cerrs"
+	"errors"
+	"fmt"
+	"fomrs/internal/api/v1/answers/presentation/dtos"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *AnswerController) Upload(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	entry.Info("Creating answer with files")
+
+	cc := customctx.NewCustomContext(ctx.Request.Context())
+
+	// El tope global se aplica mientras se lee el cuerpo, antes de procesar el
+	// multipart. El form_id viaja dentro del cuerpo, así que el límite propio
+	// del formulario lo comprueba el servicio con los bytes leídos.
+	limit := c.uploadMaxBodySize
+
+	if ctx.Request.ContentLength > limit {
+		entry.Error("Upload too large: ", ctx.Request.ContentLength)
+		uploadTooLarge(ctx, cc, limit)
+		return
+	}
+
+	body := &countingReader{ReadCloser: http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit)}
+	ctx.Request.Body = body
+
+	if err := ctx.Request.ParseMultipartForm(32 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			entry.Error("Upload too large", err)
+			uploadTooLarge(ctx, cc, limit)
+			return
+		}
+		// El resto de errores los informa BindFormData.
+	}
+
+	dto := cdtos.BindFormData[dtos.UploadAnswerDTO](ctx, cc)
+
+	if dto.Error != nil {
+		entry.Error("Error getting form data", dto.Error)
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	if err := dto.Data.Parse(ctx.Request.MultipartForm); err != nil {
+		entry.Error("Invalid payload", err)
+		response := utils.Response[dtos.UploadAnswerDTO]{
+			StatusCode: http.StatusUnprocessableEntity,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(http.StatusUnprocessableEntity, err.Error(), "dto.validate.UploadAnswerDTO"),
+			),
+		}
+		ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+		return
+	}
+
+	command := dto.Data.ToCommand()
+	command.ClientIP = ctx.ClientIP()
+	command.UserAgent = ctx.Request.UserAgent()
+	command.Query, command.Context = requestParams(ctx, command.UserID)
+	command.BodySize = body.read
+
+	response := c.service.CreateWithFiles(cc, &command)
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+
+}
+
+// countingReader cuenta los bytes leídos del cuerpo de la petición.
+type countingReader struct {
+	io.ReadCloser
+	read int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.read += int64(n)
+	return n, err
+}
+
+func uploadTooLarge(ctx *gin.Context, cc *customctx.CustomContext, limit int64) {
+	response := utils.Response[dtos.UploadAnswerDTO]{
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Success:    false,
+		Error: cc.NewError(
+			cerrs.NewCustomError(
+				http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("Request body must not exceed %d bytes", limit),
+				"forms.create.answer.payload_too_large",
+			),
+		),
+	}
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *AnswerController) DownloadFile(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx)
+
+	id := ctx.Param("id")
+	questionID := ctx.Param("question_id")
+
+	if id == "" || questionID == "" {
+		entry.Error("id and question_id are required")
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id and question_id are required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+
+	response := c.service.DownloadFile(cc, id, questionID)
+
+	if response.Error != nil {
+		ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+		return
+	}
+
+	defer response.Data.Body.Close()
+
+	file := response.Data.File
+
+	ctx.DataFromReader(http.StatusOK, file.Size, file.ContentType, response.Data.Body, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}),
+	})
+}
@@ -1,7 +1,6 @@
 package dtos
 
 import (
-	"common/utils/ctypes"
 	"errors"
 	"fomrs/internal/api/v1/answers/domain/commands"
 	"fomrs/internal/api/v1/answers/domain/entities"
@@ -11,11 +10,13 @@ type AnswerDTO struct {
 	QuestionID string   `json:"question_id" binding:"required"`
 	Answer     string   `json:"answer"`
 	Values     []string `json:"values"`
+
+	Matrix []entities.MatrixAnswer `json:"matrix"`
 }
 
 func (a AnswerDTO) Validate() error {
-	if a.Answer == "" && len(a.Values) == 0 {
-		return errors.New("answer or values are required")
+	if a.Answer == "" && len(a.Values) == 0 && len(a.Matrix) == 0 {
+		return errors.New("answer, values or matrix are required")
 	}
 	return nil
 }
@@ -26,6 +27,7 @@ func (a AnswerDTO) ToEntity() entities.AnswerEntity {
 		QuestionID: a.QuestionID,
 		Answer:     a.Answer,
 		Values:     a.Values,
+		Matrix:     a.Matrix,
 	}
 }
 
@@ -36,24 +38,14 @@ type CreateAnswerDTO struct {
 }
 
 func (c CreateAnswerDTO) Validate() error {
-	for _, answer := range c.Responses {
-		if err := answer.Validate(); err != nil {
-			return err
-		}
-	}
-	return nil
+	return validateAnswers(c.Responses)
 }
 
 func (dto CreateAnswerDTO) ToCommand() commands.ResponseCommand {
 
 	return commands.ResponseCommand{
-		FormID: dto.FormID,
-		UserID: dto.UserID,
-		Responses: ctypes.Map(
-			dto.Responses,
-			func(answer AnswerDTO) entities.AnswerEntity {
-				return answer.ToEntity()
-			},
-		),
+		FormID:    dto.FormID,
+		UserID:    dto.UserID,
+		Responses: toEntities(dto.Responses),
 	}
 }
@@ -0,0 +1,59 @@
+package dtos
+
+import (
+	"common/utils/ctypes"
+	"fomrs/internal/api/v1/answers/domain/commands"
+	"fomrs/internal/api/v1/answers/domain/entities"
+)
+
+type CreateDraftDTO struct {
+	FormID    string      `json:"form_id" binding:"required"`
+	UserID    string      `json:"user_id"`
+	Responses []AnswerDTO `json:"responses"`
+}
+
+func (dto CreateDraftDTO) Validate() error {
+	return validateAnswers(dto.Responses)
+}
+
+func (dto CreateDraftDTO) ToCommand() commands.CreateDraftCommand {
+	return commands.CreateDraftCommand{
+		FormID:    dto.FormID,
+		UserID:    dto.UserID,
+		Responses: toEntities(dto.Responses),
+	}
+}
+
+type UpdateDraftDTO struct {
+	Page      string      `json:"page"`
+	Responses []AnswerDTO `json:"responses" binding:"required"`
+}
+
+func (dto UpdateDraftDTO) Validate() error {
+	return validateAnswers(dto.Responses)
+}
+
+func (dto UpdateDraftDTO) ToCommand() commands.UpdateDraftCommand {
+	return commands.UpdateDraftCommand{
+		Page:      dto.Page,
+		Responses: toEntities(dto.Responses),
+	}
+}
+
+func validateAnswers(answers []AnswerDTO) error {
+	for _, answer := range answers {
+		if err := answer.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toEntities(answers []AnswerDTO) []entities.AnswerEntity {
+	return ctypes.Map(
+		answers,
+		func(answer AnswerDTO) entities.AnswerEntity {
+			return answer.ToEntity()
+		},
+	)
+}
@@ -0,0 +1,23 @@
+package dtos
+
+import (
+	"errors"
+	"fomrs/internal/api/v1/answers/domain/commands"
+)
+
+type UpdateAnswerDTO struct {
+	Responses []AnswerDTO `json:"responses" binding:"required"`
+}
+
+func (dto UpdateAnswerDTO) Validate() error {
+	if len(dto.Responses) == 0 {
+		return errors.New("responses must not be empty")
+	}
+	return validateAnswers(dto.Responses)
+}
+
+func (dto UpdateAnswerDTO) ToCommand() commands.UpdateAnswerCommand {
+	return commands.UpdateAnswerCommand{
+		Responses: toEntities(dto.Responses),
+	}
+}
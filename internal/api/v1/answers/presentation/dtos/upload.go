@@ -0,0 +1,57 @@
+package dtos
+
+import (
+	"encoding/json"
+	"errors"
+	"fomrs/internal/api/v1/answers/domain/commands"
+	"mime/multipart"
+)
+
+// UploadAnswerDTO es el cuerpo multipart de una respuesta con archivos. El
+// campo payload lleva el mismo JSON que CreateAnswerDTO y cada archivo se
+// envía en un campo con el id de su pregunta.
+type UploadAnswerDTO struct {
+	Payload string `form:"payload" binding:"required"`
+
+	answer CreateAnswerDTO
+	files  map[string][]*multipart.FileHeader
+}
+
+func (dto *UploadAnswerDTO) Parse(form *multipart.Form) error {
+
+	if err := json.Unmarshal([]byte(dto.Payload), &dto.answer); err != nil {
+		return errors.New("payload must be a valid JSON answer")
+	}
+
+	if dto.answer.FormID == "" {
+		return errors.New("form_id is required")
+	}
+
+	dto.files = form.File
+
+	return dto.Validate()
+}
+
+// Validate permite respuestas vacías para las preguntas cuyo valor es el
+// archivo adjunto.
+func (dto UploadAnswerDTO) Validate() error {
+	for _, answer := range dto.answer.Responses {
+		if answer.QuestionID == "" {
+			return errors.New("question_id is required")
+		}
+		if len(dto.files[answer.QuestionID]) > 0 {
+			continue
+		}
+		if err := answer.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (dto UploadAnswerDTO) ToCommand() commands.UploadAnswerCommand {
+	return commands.UploadAnswerCommand{
+		ResponseCommand: dto.answer.ToCommand(),
+		Files:           dto.files,
+	}
+}
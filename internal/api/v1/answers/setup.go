@@ -1,11 +1,17 @@
 package answers
 
 import (
+	"context"
 	"fomrs/internal/api/v1/answers/app/services"
 	"fomrs/internal/api/v1/answers/presentation/controllers"
 	"fomrs/internal/core/settings"
 	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/db/mongo/counters"
+	"fomrs/internal/db/mongo/drafts"
 	"fomrs/internal/db/mongo/forms"
+	"fomrs/internal/db/mongo/history"
+	"fomrs/internal/storage"
+	"log"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,14 +30,65 @@ func SetupAnswersModule(r *gin.Engine) {
 		"answers",
 	)
 
+	if err := answersRepository.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Error creating answers indexes: %v", err)
+	}
+
+	countersRepository := counters.NewFormCountersMongoRepository(
+		settings.Settings.MONGO_DSN,
+		"forms_db",
+		"form_counters",
+	)
+
+	draftsRepository := drafts.NewDraftsMongoRepository(
+		settings.Settings.MONGO_DSN,
+		"forms_db",
+		"answer_drafts",
+	)
+
+	if err := draftsRepository.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Error creating answer_drafts indexes: %v", err)
+	}
+
+	historyRepository := history.NewAnswerHistoryMongoRepository(
+		settings.Settings.MONGO_DSN,
+		"forms_db",
+		"answer_history",
+	)
+
+	// Storage
+	fileStorage, err := storage.New(storage.Config{
+		Driver:      settings.Settings.STORAGE_DRIVER,
+		LocalDir:    settings.Settings.STORAGE_LOCAL_DIR,
+		S3Endpoint:  settings.Settings.S3_ENDPOINT,
+		S3Region:    settings.Settings.S3_REGION,
+		S3Bucket:    settings.Settings.S3_BUCKET,
+		S3AccessKey: settings.Settings.S3_ACCESS_KEY,
+		S3SecretKey: settings.Settings.S3_SECRET_KEY,
+	})
+
+	if err != nil {
+		log.Fatalf("Error configuring file storage: %v", err)
+	}
+
 	// Services
-	service := services.NewAnswerService(formsRepository, answersRepository)
+	service := services.NewAnswerService(formsRepository, answersRepository, countersRepository, draftsRepository, historyRepository, settings.Settings.DRAFT_TTL, fileStorage, settings.Settings.UPLOAD_MAX_SIZE)
 
 	// Controllers
-	controller := controllers.NewAnswerController(service)
+	controller := controllers.NewAnswerController(service, settings.Settings.UPLOAD_MAX_BODY_SIZE)
 
 	// Routes
 	answers := r.Group("/v1/answers")
 	answers.POST("", controller.Create)
+	answers.POST("/upload", controller.Upload)
 	answers.GET("/:id", controller.Retrieve)
+	answers.PATCH("/:id", controller.Update)
+	answers.DELETE("/:id", controller.Delete)
+	answers.GET("/:id/history", controller.History)
+	answers.GET("/:id/files/:question_id", controller.DownloadFile)
+
+	answers.POST("/drafts", controller.CreateDraft)
+	answers.GET("/drafts/:id", controller.RetrieveDraft)
+	answers.PATCH("/drafts/:id", controller.UpdateDraft)
+	answers.POST("/drafts/:id/submit", controller.SubmitDraft)
 }
@@ -5,29 +5,99 @@ import (
 	"common/domain/customctx"
 	"common/domain/logger"
 	"common/utils"
+	"common/utils/cerrs"
+	"encoding/base64"
+	"fomrs/internal/api/v1/forms/domain/commands"
 	"fomrs/internal/db/mongo/answers"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-func (s *FormsService) Answers(cc *customctx.CustomContext, id string) utils.Response[answers.AnswerListModel] {
+// Answers lista las respuestas de un formulario paginando por número de página
+// o por cursor. El cursor lleva el created_at y el _id de la última respuesta
+// devuelta, que son las claves del orden.
+func (s *FormsService) Answers(cc *customctx.CustomContext, id string, command commands.ListAnswersCommand) utils.Response[answers.AnswerListModel] {
 
 	entry := logger.FromContext(cc.Context())
 
 	entry.Info("Retrieving answers of form: ", id)
 
-	cri := criteria.Criteria{
-		Filters: *criteria.NewFilters(
-			[]criteria.Filter{
-				{
-					Field:    "form_id",
-					Operator: criteria.OperatorEqual,
-					Value:    id,
-				},
-			},
-		),
+	cri := command.Criteria
+
+	filters := answersFilters(id, cri, command.QuestionID, command.Value)
+
+	cri.Filters = *criteria.NewFilters(filters)
+
+	// El orden es siempre created_at y, para desempatar, _id: ambos forman la
+	// clave del cursor.
+	if len(cri.Orders) == 0 {
+		cri.Orders = []criteria.Order{criteria.NewOrder("created_at", criteria.OrderTypeAsc)}
+	}
+
+	descending := cri.Orders[0].IsDesc()
+
+	direction := criteria.OrderTypeAsc
+	if descending {
+		direction = criteria.OrderTypeDesc
+	}
+
+	cri.Orders = append(cri.Orders, criteria.NewOrder("_id", direction))
+
+	total := s.answersRepository.Count(cc.Context(), cri)
+
+	if total.Err != nil {
+		entry.Error("Error counting answers", total.Err)
+		return utils.Response[answers.AnswerListModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      total.Err,
+		}
+	}
+
+	pagination := &utils.Pagination{
+		Total: total.Data,
+		Limit: cri.Limit,
+	}
+
+	if command.Cursor != "" {
+		afterTime, afterID, err := decodeCursor(command.Cursor)
+		if err != nil {
+			entry.Error("Invalid cursor", err)
+			return utils.Response[answers.AnswerListModel]{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Error:      cc.NewError(err),
+			}
+		}
+
+		operator := criteria.OperatorGreaterThan
+		if descending {
+			operator = criteria.OperatorLessThan
+		}
+
+		// Siguiente en el orden (created_at, _id): created_at posterior o, con
+		// el mismo created_at, _id posterior.
+		after := criteria.Or(
+			criteria.Where("created_at", operator, afterTime),
+			criteria.And(
+				criteria.Where("created_at", criteria.OperatorEqual, afterTime),
+				criteria.Where("_id", operator, afterID),
+			),
+		)
+		if cri.Where != nil {
+			after = criteria.And(*cri.Where, after)
+		}
+		cri.Where = &after
+		cri.Offset = 0
+	} else if cri.Limit > 0 {
+		pagination.Page = cri.Offset/cri.Limit + 1
 	}
 
-	answersResults := s.answersRepository.Matching(cri, "answers", 0, 10)
+	answersResults := s.answersRepository.Matching(cri, "answers", 0, 0)
 
 	if answersResults.Err != nil {
 		entry.Error("Error retrieving answers", answersResults.Err)
@@ -38,9 +108,88 @@ func (s *FormsService) Answers(cc *customctx.CustomContext, id string) utils.Res
 		}
 	}
 
+	if cri.Limit > 0 && len(answersResults.Data) == cri.Limit {
+		last := answersResults.Data[len(answersResults.Data)-1]
+		pagination.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+
 	return utils.Response[answers.AnswerListModel]{
 		StatusCode: http.StatusOK,
 		Success:    true,
 		Results:    answersResults.Data,
+		Pagination: pagination,
+	}
+}
+
+// answersFilters añade a los filtros del criteria los de las respuestas no
+// eliminadas del formulario y, si se indica, el de la pregunta y su valor.
+func answersFilters(id string, cri criteria.Criteria, questionID string, value string) []criteria.Filter {
+
+	filters := append(cri.Filters.Get(),
+		criteria.Filter{
+			Field:    "form_id",
+			Operator: criteria.OperatorEqual,
+			Value:    id,
+		},
+		// Las respuestas eliminadas (borrado lógico) no se listan.
+		criteria.Filter{
+			Field:    "deleted_at",
+			Operator: criteria.OperatorExists,
+			Value:    false,
+		},
+	)
+
+	if questionID != "" {
+		match := criteria.Where(answers.FieldQuestionID, criteria.OperatorEqual, questionID)
+		if value != "" {
+			// El valor puede venir en answer o, en preguntas de varios valores, en values.
+			match = criteria.And(
+				match,
+				criteria.Or(
+					criteria.Where(answers.FieldAnswer, criteria.OperatorEqual, value),
+					criteria.Where(answers.FieldValues, criteria.OperatorContains, value),
+				),
+			)
+		}
+		filters = append(filters, criteria.Filter{
+			Field:    answers.FieldAnswers,
+			Operator: criteria.OperatorElemMatch,
+			Value:    match,
+		})
 	}
+
+	return filters
+}
+
+// encodeCursor codifica created_at (en milisegundos, la precisión con que lo
+// guarda Mongo) y el _id de la respuesta.
+func encodeCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(createdAt.UnixMilli(), 10) + ":" + id))
+}
+
+func decodeCursor(cursor string) (time.Time, primitive.ObjectID, *cerrs.CustomError) {
+
+	invalid := cerrs.NewCustomError(http.StatusBadRequest, "Invalid cursor", "forms.answers.invalid_cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, primitive.NilObjectID, invalid
+	}
+
+	millis, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return time.Time{}, primitive.NilObjectID, invalid
+	}
+
+	ms, err := strconv.ParseInt(millis, 10, 64)
+	if err != nil {
+		return time.Time{}, primitive.NilObjectID, invalid
+	}
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return time.Time{}, primitive.NilObjectID, invalid
+	}
+
+	return time.UnixMilli(ms).UTC(), oid, nil
 }
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"fomrs/internal/api/v1/forms/domain/commands"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/forms"
+	utils_internal "fomrs/internal/utils"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"common/utils/ctypes"
+
+	"github.com/google/uuid"
+)
+
+// Clone copia el formulario en un borrador nuevo con ids de pregunta nuevos.
+// La copia conserva si es plantilla y registra de qué versión procede.
+func (s *FormsService) Clone(cc *customctx.CustomContext, id string, command commands.CloneFormCommand) utils.Response[forms.FormModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Cloning form: ", id)
+
+	form := s.formsRepository.Find(cc.Context(), id)
+
+	if form.Err != nil {
+		entry.Error("Error retrieving form", form.Err)
+		return utils.Response[forms.FormModel]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error:      form.Err,
+		}
+	}
+
+	copied := copyCommand(form.Data, command.Title)
+	copied.IsTemplate = form.Data.IsTemplate
+
+	return s.CreateForm(cc, copied)
+}
+
+// Instantiate crea un formulario a partir de una plantilla sustituyendo sus
+// parámetros {{nombre}}. Todos los parámetros deben tener valor.
+func (s *FormsService) Instantiate(cc *customctx.CustomContext, id string, command commands.InstantiateTemplateCommand) utils.Response[forms.FormModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Instantiating template: ", id)
+
+	form := s.formsRepository.Find(cc.Context(), id)
+
+	if form.Err != nil {
+		entry.Error("Error retrieving form", form.Err)
+		return utils.Response[forms.FormModel]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error:      form.Err,
+		}
+	}
+
+	if !form.Data.IsTemplate {
+		entry.Error("Form is not a template")
+		return instantiateFailed(cc, "Form is not a template", "forms.instantiate.not_template")
+	}
+
+	parameters := form.Data.TemplateParameters()
+
+	missing := []string{}
+	for _, parameter := range parameters {
+		if _, ok := command.Parameters[parameter]; !ok {
+			missing = append(missing, parameter)
+		}
+	}
+	if len(missing) > 0 {
+		return instantiateFailed(cc, "Missing template parameters: "+strings.Join(missing, ", "), "forms.instantiate.missing_parameters")
+	}
+
+	unknown := []string{}
+	for parameter := range command.Parameters {
+		if !slices.Contains(parameters, parameter) {
+			unknown = append(unknown, parameter)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return instantiateFailed(cc, "Unknown template parameters: "+strings.Join(unknown, ", "), "forms.instantiate.unknown_parameters")
+	}
+
+	copied := copyCommand(form.Data.WithParameters(command.Parameters), command.Title)
+	copied.Source.Template = true
+
+	return s.CreateForm(cc, copied)
+}
+
+func instantiateFailed(cc *customctx.CustomContext, message string, code string) utils.Response[forms.FormModel] {
+	return utils.Response[forms.FormModel]{
+		StatusCode: http.StatusBadRequest,
+		Success:    false,
+		Error: cc.NewError(
+			cerrs.NewCustomError(http.StatusBadRequest, message, code),
+		),
+	}
+}
+
+// copyCommand prepara la creación de la copia de un formulario. Las preguntas
+// reciben ids nuevos y se actualizan las referencias de visible_if y de las
+// variables de los campos computed. La ventana de recepción no se copia.
+func copyCommand(form forms.FormModel, title string) commands.CreateFormCommand {
+
+	ids := make(map[string]string, len(form.Questions))
+	for _, question := range form.Questions {
+		ids[question.ID] = uuid.New().String()
+	}
+
+	questions := ctypes.Map(form.Questions, func(question entities.QuestionEntity) commands.QuestionCommand {
+		return commands.QuestionCommand{
+			ID:          ids[question.ID],
+			Title:       question.Title,
+			Description: question.Description,
+			Type:        question.Type,
+			Required:    question.Required,
+			Section:     question.Section,
+			Order:       question.Order,
+			Metadata:    remapMetadata(question, ids),
+			VisibleIf:   remapCondition(question.VisibleIf, ids),
+			Scoring:     question.Scoring,
+		}
+	})
+
+	sections := slices.Clone(form.Sections)
+	for i := range sections {
+		sections[i].VisibleIf = remapCondition(sections[i].VisibleIf, ids)
+	}
+
+	if title == "" {
+		title = form.Title
+	}
+
+	return commands.CreateFormCommand{
+		Title:       title,
+		Description: form.Description,
+		Questions:   questions,
+		Sections:    sections,
+		Settings:    form.Settings,
+		Source: &entities.FormSource{
+			FormID:  form.ID,
+			Version: form.CurrentVersion(),
+		},
+	}
+}
+
+// remapCondition copia la condición apuntando a los ids nuevos.
+func remapCondition(condition *entities.Condition, ids map[string]string) *entities.Condition {
+
+	if condition == nil {
+		return nil
+	}
+
+	copied := *condition
+	if id, ok := ids[copied.QuestionID]; ok {
+		copied.QuestionID = id
+	}
+
+	copied.All = remapConditions(condition.All, ids)
+	copied.Any = remapConditions(condition.Any, ids)
+
+	return &copied
+}
+
+func remapConditions(conditions []entities.Condition, ids map[string]string) []entities.Condition {
+	if conditions == nil {
+		return nil
+	}
+	return ctypes.Map(conditions, func(condition entities.Condition) entities.Condition {
+		return *remapCondition(&condition, ids)
+	})
+}
+
+// remapMetadata copia la metadata de los campos computed apuntando sus
+// variables a los ids nuevos; el resto de preguntas la comparten.
+func remapMetadata(question entities.QuestionEntity, ids map[string]string) map[string]any {
+
+	if utils_internal.QuestionType(question.Type) != utils_internal.QuestionTypeComputed {
+		return question.Metadata
+	}
+
+	variables, ok, err := utils_internal.MetadataStringMap(question.Metadata, utils_internal.MetadataVariables)
+	if !ok || err != nil {
+		return question.Metadata
+	}
+
+	remapped := make(map[string]any, len(variables))
+	for name, questionID := range variables {
+		if id, ok := ids[questionID]; ok {
+			questionID = id
+		}
+		remapped[name] = questionID
+	}
+
+	metadata := maps.Clone(question.Metadata)
+	metadata[utils_internal.MetadataVariables] = remapped
+
+	return metadata
+}
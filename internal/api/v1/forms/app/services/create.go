@@ -6,7 +6,9 @@ import (
 	"fomrs/internal/api/v1/forms/domain/commands"
 	"fomrs/internal/api/v1/forms/domain/entities"
 	"fomrs/internal/db/mongo/forms"
+	utils_internal "fomrs/internal/utils"
 	"net/http"
+	"time"
 
 	"common/utils/ctypes"
 
@@ -15,17 +17,22 @@ import (
 
 func (s *FormsService) CreateForm(cc *customctx.CustomContext, command commands.CreateFormCommand) utils.Response[forms.FormModel] {
 
+	now := time.Now().UTC()
+
 	form := forms.FormModel{
 		Title:       command.Title,
 		Description: command.Description,
-		Questions: ctypes.Map(
-			command.Questions,
-			func(question commands.QuestionCommand) entities.QuestionEntity {
-				entity := question.ToEntity()
-				entity.ID = uuid.New().String()
-				return entity
-			},
-		),
+		Questions:   buildQuestions(command.Questions),
+		Sections:    entities.NormalizeSections(command.Sections),
+		Version:     1,
+		Status:      entities.FormStatusDraft,
+		OpensAt:     command.OpensAt,
+		ClosesAt:    command.ClosesAt,
+		Settings:    command.Settings,
+		IsTemplate:  command.IsTemplate,
+		Source:      command.Source,
+		CreatedAt:   now,
+		UpdatedAt:   now,
 	}
 
 	model := s.formsRepository.Save(cc.Context(), form)
@@ -40,9 +47,60 @@ func (s *FormsService) CreateForm(cc *customctx.CustomContext, command commands.
 
 	form.ID = model.Data
 
+	revision := s.saveRevision(cc, form)
+
+	if revision.Err != nil {
+		return utils.Response[forms.FormModel]{
+			Data:       form,
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(revision.Err),
+		}
+	}
+
 	return utils.Response[forms.FormModel]{
 		Data:       form,
 		StatusCode: http.StatusCreated,
 		Success:    true,
 	}
 }
+
+// buildQuestions convierte los comandos en entidades ordenadas conservando los ids
+// enviados por el cliente y generando uno nuevo para las preguntas sin id.
+func buildQuestions(questions []commands.QuestionCommand) []entities.QuestionEntity {
+	return entities.NormalizeQuestions(ctypes.Map(
+		questions,
+		func(question commands.QuestionCommand) entities.QuestionEntity {
+			entity := question.ToEntity()
+			if entity.ID == "" {
+				entity.ID = uuid.New().String()
+			}
+			entity.Scoring = normalizeScoring(entity)
+			return entity
+		},
+	))
+}
+
+// normalizeScoring guarda las respuestas correctas de las preguntas de un solo
+// valor en la misma forma canónica que las respuestas (E.164, fechas ISO...).
+func normalizeScoring(question entities.QuestionEntity) *entities.QuestionScoring {
+
+	questionType := utils_internal.QuestionType(question.Type)
+
+	if question.Scoring == nil ||
+		utils_internal.IsMultiValue(questionType) ||
+		utils_internal.IsMatrix(questionType) ||
+		utils_internal.IsServerSide(questionType) {
+		return question.Scoring
+	}
+
+	validator, err := utils_internal.NewValidator(questionType, question.Metadata)
+	if err != nil {
+		return question.Scoring
+	}
+
+	scoring := *question.Scoring
+	scoring.CorrectAnswers = utils_internal.NormalizeValues(validator, scoring.CorrectAnswers)
+
+	return &scoring
+}
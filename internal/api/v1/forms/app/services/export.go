@@ -0,0 +1,280 @@
+package services
+
+import (
+	"common/domain/criteria"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	answersEntities "fomrs/internal/api/v1/answers/domain/entities"
+	"fomrs/internal/api/v1/forms/domain/commands"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/db/mongo/forms"
+	"fomrs/internal/export"
+	utils_internal "fomrs/internal/utils"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportSeparator une los valores de las preguntas de varios valores; las
+// opciones pueden contener comas.
+const exportSeparator = "; "
+
+// AnswersExport es una exportación lista para escribirse en la respuesta HTTP.
+type AnswersExport struct {
+	Filename    string
+	ContentType string
+
+	write func(w io.Writer) error
+}
+
+// Write escribe la exportación leyendo las respuestas con un cursor. Si falla
+// a mitad, lo ya escrito no se puede deshacer.
+func (e AnswersExport) Write(w io.Writer) error {
+	return e.write(w)
+}
+
+// exportColumn es una columna con la forma de obtener su valor.
+type exportColumn struct {
+	export.Column
+	value func(answer answers.AnswerModel, responses map[string]answersEntities.AnswerEntity) string
+}
+
+// ExportAnswers prepara la exportación de las respuestas de un formulario con
+// una fila por respuesta y una columna por pregunta de la versión vigente.
+func (s *FormsService) ExportAnswers(cc *customctx.CustomContext, id string, command commands.ExportAnswersCommand) utils.Response[AnswersExport] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Exporting answers of form: ", id)
+
+	form := s.formsRepository.Find(cc.Context(), id)
+
+	if form.Err != nil {
+		entry.Error("Error retrieving form", form.Err)
+		return utils.Response[AnswersExport]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error:      form.Err,
+		}
+	}
+
+	cri := command.Criteria
+	cri.Filters = *criteria.NewFilters(answersFilters(id, cri, command.QuestionID, command.Value))
+
+	direction := criteria.OrderTypeAsc
+	if len(cri.Orders) > 0 && cri.Orders[0].IsDesc() {
+		direction = criteria.OrderTypeDesc
+	}
+	cri.Orders = append(cri.Orders, criteria.NewOrder("_id", direction))
+
+	// Se exportan todas las respuestas que cumplen los filtros.
+	cri.Limit, cri.Offset = 0, 0
+
+	columns := exportColumns(form.Data, command.SplitOptions)
+
+	header := make([]export.Column, len(columns))
+	for i, column := range columns {
+		header[i] = column.Column
+	}
+
+	ctx := cc.Context()
+
+	return utils.Response[AnswersExport]{
+		Data: AnswersExport{
+			Filename:    "form-" + id + "-answers." + command.Format,
+			ContentType: export.ContentType(command.Format),
+			write: func(w io.Writer) error {
+
+				writer, err := export.New(command.Format, w)
+				if err != nil {
+					return err
+				}
+
+				if err := writer.WriteHeader(header); err != nil {
+					return err
+				}
+
+				err = s.answersRepository.Each(ctx, cri, func(answer answers.AnswerModel) error {
+					responses := make(map[string]answersEntities.AnswerEntity, len(answer.Answers))
+					for _, response := range answer.Answers {
+						responses[response.QuestionID] = response
+					}
+					row := make([]string, len(columns))
+					for i, column := range columns {
+						row[i] = column.value(answer, responses)
+					}
+					return writer.WriteRow(row)
+				})
+				if err != nil {
+					return err
+				}
+
+				return writer.Close()
+			},
+		},
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// exportColumns construye las columnas: los datos de la respuesta y, después,
+// las preguntas en su orden. Los títulos repetidos se numeran.
+func exportColumns(form forms.FormModel, splitOptions bool) []exportColumn {
+
+	columns := []exportColumn{
+		{Column: export.Column{Title: "id"}, value: func(answer answers.AnswerModel, _ map[string]answersEntities.AnswerEntity) string {
+			return answer.ID
+		}},
+		{Column: export.Column{Title: "user_id"}, value: func(answer answers.AnswerModel, _ map[string]answersEntities.AnswerEntity) string {
+			return answer.UserID
+		}},
+		{Column: export.Column{Title: "form_version", Numeric: true}, value: func(answer answers.AnswerModel, _ map[string]answersEntities.AnswerEntity) string {
+			return strconv.Itoa(answer.FormVersion)
+		}},
+		{Column: export.Column{Title: "created_at"}, value: func(answer answers.AnswerModel, _ map[string]answersEntities.AnswerEntity) string {
+			return answer.CreatedAt.UTC().Format(time.RFC3339)
+		}},
+		{Column: export.Column{Title: "updated_at"}, value: func(answer answers.AnswerModel, _ map[string]answersEntities.AnswerEntity) string {
+			return answer.UpdatedAt.UTC().Format(time.RFC3339)
+		}},
+	}
+
+	if form.Settings.Quiz.Enabled {
+		columns = append(columns, exportColumn{
+			Column: export.Column{Title: "score", Numeric: true},
+			value: func(answer answers.AnswerModel, _ map[string]answersEntities.AnswerEntity) string {
+				if answer.Score == nil {
+					return ""
+				}
+				return strconv.FormatFloat(answer.Score.Points, 'f', -1, 64)
+			},
+		})
+	}
+
+	for _, question := range form.Questions {
+		columns = append(columns, questionColumns(question, splitOptions)...)
+	}
+
+	seen := map[string]int{}
+	for i := range columns {
+		title := columns[i].Title
+		seen[title]++
+		if seen[title] > 1 {
+			columns[i].Title = title + " (" + strconv.Itoa(seen[title]) + ")"
+		}
+	}
+
+	return columns
+}
+
+// questionColumns devuelve las columnas de una pregunta: una por fila en las
+// matrices y, con splitOptions, una por opción en las de varios valores.
+func questionColumns(question entities.QuestionEntity, splitOptions bool) []exportColumn {
+
+	title := question.Title
+	if title == "" {
+		title = question.ID
+	}
+
+	questionType := utils_internal.QuestionType(question.Type)
+
+	switch {
+	case questionType == utils_internal.QuestionTypeMatrix:
+		rows, _, _ := utils_internal.MetadataStrings(question.Metadata, utils_internal.MetadataRows)
+		columns := make([]exportColumn, 0, len(rows))
+		for _, row := range rows {
+			columns = append(columns, exportColumn{
+				Column: export.Column{Title: title + ": " + row},
+				value: func(_ answers.AnswerModel, responses map[string]answersEntities.AnswerEntity) string {
+					response := responses[question.ID]
+					i := slices.IndexFunc(response.Matrix, func(answer answersEntities.MatrixAnswer) bool { return answer.Row == row })
+					if i < 0 {
+						return ""
+					}
+					return strings.Join(response.Matrix[i].Columns, exportSeparator)
+				},
+			})
+		}
+		return columns
+
+	case utils_internal.IsMultiValue(questionType):
+		options, _, _ := utils_internal.MetadataStrings(question.Metadata, utils_internal.MetadataOptions)
+		if !splitOptions || len(options) == 0 {
+			return []exportColumn{{
+				Column: export.Column{Title: title},
+				value: func(_ answers.AnswerModel, responses map[string]answersEntities.AnswerEntity) string {
+					return strings.Join(exportValues(responses[question.ID]), exportSeparator)
+				},
+			}}
+		}
+		// Con una columna por opción se marca 1 o 0; vacío si no se respondió.
+		columns := make([]exportColumn, 0, len(options))
+		for _, option := range options {
+			columns = append(columns, exportColumn{
+				Column: export.Column{Title: title + ": " + option, Numeric: true},
+				value: func(_ answers.AnswerModel, responses map[string]answersEntities.AnswerEntity) string {
+					values := exportValues(responses[question.ID])
+					if len(values) == 0 {
+						return ""
+					}
+					if slices.Contains(values, option) {
+						return "1"
+					}
+					return "0"
+				},
+			})
+		}
+		return columns
+
+	case questionType == utils_internal.QuestionTypeFile:
+		return []exportColumn{{
+			Column: export.Column{Title: title},
+			value: func(_ answers.AnswerModel, responses map[string]answersEntities.AnswerEntity) string {
+				response := responses[question.ID]
+				if response.File != nil {
+					return response.File.Name
+				}
+				return response.Answer
+			},
+		}}
+	}
+
+	return []exportColumn{{
+		Column: export.Column{Title: title, Numeric: isNumericType(questionType)},
+		value: func(_ answers.AnswerModel, responses map[string]answersEntities.AnswerEntity) string {
+			return responses[question.ID].Answer
+		},
+	}}
+}
+
+// exportValues devuelve los valores de una pregunta de varios valores; las
+// respuestas antiguas de checkbox los guardan separados por comas en answer.
+func exportValues(response answersEntities.AnswerEntity) []string {
+
+	if len(response.Values) > 0 || response.Answer == "" {
+		return response.Values
+	}
+
+	values := strings.Split(response.Answer, ",")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
+
+func isNumericType(questionType utils_internal.QuestionType) bool {
+	switch questionType {
+	case utils_internal.QuestionTypeNumber,
+		utils_internal.QuestionTypeInteger,
+		utils_internal.QuestionTypeRating,
+		utils_internal.QuestionTypeScale,
+		utils_internal.QuestionTypeNPS:
+		return true
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"common/domain/criteria"
 	"common/domain/customctx"
 	"common/domain/logger"
 	"common/utils"
@@ -8,13 +9,37 @@ import (
 	"net/http"
 )
 
-func (s *FormsService) List(cc *customctx.CustomContext) utils.Response[forms.FormListModel] {
+// List lista los formularios o, con templates, la biblioteca de plantillas.
+func (s *FormsService) List(cc *customctx.CustomContext, cri criteria.Criteria, templates bool) utils.Response[forms.FormListModel] {
 
 	entry := logger.FromContext(cc.Context())
 
-	entry.Info("Listing forms")
+	entry.Info("Listing forms, templates: ", templates)
 
-	formsResult := s.formsRepository.FindAll(cc.Context())
+	// $ne también incluye los formularios sin el campo is_template.
+	operator := criteria.OperatorNotEqual
+	if templates {
+		operator = criteria.OperatorEqual
+	}
+
+	cri.Filters = *criteria.NewFilters(append(cri.Filters.Get(), criteria.Filter{
+		Field:    "is_template",
+		Operator: operator,
+		Value:    true,
+	}))
+
+	total := s.formsRepository.Count(cc.Context(), cri)
+
+	if total.Err != nil {
+		entry.Error("Error counting forms", total.Err)
+		return utils.Response[forms.FormListModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      total.Err,
+		}
+	}
+
+	formsResult := s.formsRepository.Matching(cri, "forms", 0, 0)
 
 	if formsResult.Err != nil {
 		entry.Error("Error listing forms", formsResult.Err)
@@ -25,9 +50,18 @@ func (s *FormsService) List(cc *customctx.CustomContext) utils.Response[forms.Fo
 		}
 	}
 
+	pagination := &utils.Pagination{
+		Total: total.Data,
+		Limit: cri.Limit,
+	}
+	if cri.Limit > 0 {
+		pagination.Page = cri.Offset/cri.Limit + 1
+	}
+
 	return utils.Response[forms.FormListModel]{
 		StatusCode: http.StatusOK,
 		Success:    true,
 		Results:    formsResult.Data,
+		Pagination: pagination,
 	}
 }
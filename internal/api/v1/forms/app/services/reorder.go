@@ -0,0 +1,145 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"common/utils/ctypes"
+	"fomrs/internal/api/v1/forms/domain/commands"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/forms"
+	"net/http"
+	"slices"
+)
+
+// ReorderSections cambia el orden de las secciones sin reenviar el formulario.
+// Las secciones implícitas de formularios antiguos pasan a declararse.
+func (s *FormsService) ReorderSections(cc *customctx.CustomContext, id string, command commands.ReorderSectionsCommand) utils.Response[forms.FormDetailModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Reordering sections of form id: ", id)
+
+	form := s.findEditableForm(cc, id)
+
+	if form.Error != nil {
+		return utils.Response[forms.FormDetailModel]{
+			StatusCode: form.StatusCode,
+			Success:    false,
+			Error:      form.Error,
+		}
+	}
+
+	// Las preguntas sin sección siempre se muestran al final.
+	sections := slices.DeleteFunc(
+		entities.LayoutSections(form.Data.Questions, form.Data.Sections),
+		func(section entities.SectionEntity) bool { return section.ID == "" },
+	)
+
+	if err := checkPermutation(
+		ctypes.Map(sections, func(section entities.SectionEntity) string { return section.ID }),
+		command.SectionIDs,
+		"forms.reorder.invalid_sections",
+	); err != nil {
+		entry.Error("Invalid sections order", err)
+		return utils.Response[forms.FormDetailModel]{
+			StatusCode: err.Code,
+			Success:    false,
+			Error:      cc.NewError(err),
+		}
+	}
+
+	for i := range sections {
+		sections[i].Order = slices.Index(command.SectionIDs, sections[i].ID) + 1
+	}
+
+	return detailResponse(s.publishRevision(cc, form.Data, map[string]interface{}{
+		"sections": entities.NormalizeSections(sections),
+	}))
+}
+
+// ReorderQuestions cambia el orden de las preguntas de una sección.
+func (s *FormsService) ReorderQuestions(cc *customctx.CustomContext, id string, command commands.ReorderQuestionsCommand) utils.Response[forms.FormDetailModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Reordering questions of form id: ", id)
+
+	form := s.findEditableForm(cc, id)
+
+	if form.Error != nil {
+		return utils.Response[forms.FormDetailModel]{
+			StatusCode: form.StatusCode,
+			Success:    false,
+			Error:      form.Error,
+		}
+	}
+
+	questions := slices.Clone(form.Data.Questions)
+
+	var current []string
+	for _, question := range questions {
+		if question.Section == command.SectionID {
+			current = append(current, question.ID)
+		}
+	}
+
+	if err := checkPermutation(current, command.QuestionIDs, "forms.reorder.invalid_questions"); err != nil {
+		entry.Error("Invalid questions order", err)
+		return utils.Response[forms.FormDetailModel]{
+			StatusCode: err.Code,
+			Success:    false,
+			Error:      cc.NewError(err),
+		}
+	}
+
+	for i := range questions {
+		if questions[i].Section == command.SectionID {
+			questions[i].Order = slices.Index(command.QuestionIDs, questions[i].ID) + 1
+		}
+	}
+
+	return detailResponse(s.publishRevision(cc, form.Data, map[string]interface{}{
+		"questions": entities.NormalizeQuestions(questions),
+	}))
+}
+
+// checkPermutation valida que ids contenga exactamente los mismos elementos
+// que current.
+func checkPermutation(current []string, ids []string, scope string) *cerrs.CustomError {
+
+	if len(current) == 0 {
+		return cerrs.NewCustomError(http.StatusNotFound, "Nothing to reorder", scope)
+	}
+
+	if len(current) != len(ids) {
+		return cerrs.NewCustomError(
+			http.StatusBadRequest,
+			"The new order must include every element exactly once",
+			scope,
+		)
+	}
+
+	for _, id := range ids {
+		if !slices.Contains(current, id) {
+			return cerrs.NewCustomError(http.StatusBadRequest, "Unknown id: "+id, scope)
+		}
+	}
+	return nil
+}
+
+// detailResponse convierte la respuesta del formulario en su vista de detalle.
+func detailResponse(res utils.Response[forms.FormModel]) utils.Response[forms.FormDetailModel] {
+
+	detail := utils.Response[forms.FormDetailModel]{
+		StatusCode: res.StatusCode,
+		Success:    res.Success,
+		Error:      res.Error,
+	}
+
+	if res.Success {
+		detail.Data = res.Data.Detail()
+	}
+	return detail
+}
@@ -8,7 +8,10 @@ import (
 	"net/http"
 )
 
-func (s *FormsService) Retrieve(cc *customctx.CustomContext, id string) utils.Response[forms.FormModel] {
+// Retrieve devuelve el formulario vigente o, si version > 0, la revisión
+// indicada, con las preguntas anidadas en sus secciones. Las respuestas
+// correctas de los quizzes solo se incluyen con includeScoring.
+func (s *FormsService) Retrieve(cc *customctx.CustomContext, id string, version int, includeScoring bool) utils.Response[forms.FormDetailModel] {
 
 	entry := logger.FromContext(cc.Context())
 
@@ -18,16 +21,45 @@ func (s *FormsService) Retrieve(cc *customctx.CustomContext, id string) utils.Re
 
 	if form.Err != nil {
 		entry.Error("Error retrieving form", form.Err)
-		return utils.Response[forms.FormModel]{
+		return utils.Response[forms.FormDetailModel]{
 			StatusCode: http.StatusNotFound,
 			Success:    false,
 			Error:      form.Err,
 		}
 	}
 
-	return utils.Response[forms.FormModel]{
+	if version > 0 && version != form.Data.CurrentVersion() {
+
+		entry.Info("Retrieving form revision: ", version)
+
+		revision := s.findRevision(cc, id, version)
+
+		if revision.Err != nil {
+			entry.Error("Error retrieving form revision", revision.Err)
+			return utils.Response[forms.FormDetailModel]{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Error:      cc.NewError(revision.Err),
+			}
+		}
+
+		return utils.Response[forms.FormDetailModel]{
+			StatusCode: http.StatusOK,
+			Success:    true,
+			Data:       scoringView(revision.Data, includeScoring).Detail(),
+		}
+	}
+
+	return utils.Response[forms.FormDetailModel]{
 		StatusCode: http.StatusOK,
 		Success:    true,
-		Data:       form.Data,
+		Data:       scoringView(form.Data, includeScoring).Detail(),
+	}
+}
+
+func scoringView(form forms.FormModel, includeScoring bool) forms.FormModel {
+	if includeScoring {
+		return form
 	}
+	return form.WithoutScoring()
 }
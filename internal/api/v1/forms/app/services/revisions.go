@@ -0,0 +1,74 @@
+package services
+
+import (
+	"common/domain/criteria"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"fmt"
+	"fomrs/internal/db/mongo/forms"
+	"fomrs/internal/db/mongo/revisions"
+	"net/http"
+	"time"
+)
+
+// saveRevision guarda una copia inmutable del formulario en su versión actual.
+func (s *FormsService) saveRevision(cc *customctx.CustomContext, form forms.FormModel) utils.Result[string] {
+
+	entry := logger.FromContext(cc.Context())
+
+	revision := revisions.FormRevisionModel{
+		FormID:    form.ID,
+		Version:   form.CurrentVersion(),
+		Form:      form,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	res := s.revisionsRepository.Save(cc.Context(), revision)
+
+	if res.Err != nil {
+		entry.Error("Error saving form revision", res.Err)
+	}
+
+	return res
+}
+
+// findRevision busca la copia del formulario guardada para la versión indicada.
+func (s *FormsService) findRevision(cc *customctx.CustomContext, formID string, version int) utils.Result[forms.FormModel] {
+
+	cri := criteria.Criteria{
+		Filters: *criteria.NewFilters(
+			[]criteria.Filter{
+				{
+					Field:    "form_id",
+					Operator: criteria.OperatorEqual,
+					Value:    formID,
+				},
+				{
+					Field:    "version",
+					Operator: criteria.OperatorEqual,
+					Value:    version,
+				},
+			},
+		),
+	}
+
+	res := s.revisionsRepository.Matching(cri, "form_revisions", 0, 1)
+
+	if res.Err != nil {
+		return utils.Result[forms.FormModel]{Err: res.Err}
+	}
+
+	if len(res.Data) == 0 {
+		return utils.Result[forms.FormModel]{
+			Err: cerrs.NewCustomError(
+				http.StatusNotFound,
+				fmt.Sprintf("Form version %d not found", version),
+				"forms.retrieve.version_not_found",
+			),
+		}
+	}
+
+	return utils.Result[forms.FormModel]{Data: res.Data[0].Form}
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"fomrs/internal/jsonschema"
+	"net/http"
+)
+
+// Schema describe como JSON Schema (draft 2020-12) un envío válido de la
+// versión vigente del formulario o, si version > 0, de la revisión indicada.
+func (s *FormsService) Schema(cc *customctx.CustomContext, id string, version int) utils.Response[jsonschema.Schema] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Building JSON Schema of form: ", id)
+
+	form := s.Retrieve(cc, id, version, false)
+
+	if form.Error != nil {
+		return utils.Response[jsonschema.Schema]{
+			StatusCode: form.StatusCode,
+			Success:    false,
+			Error:      form.Error,
+		}
+	}
+
+	return utils.Response[jsonschema.Schema]{
+		Data:       jsonschema.FromForm(form.Data.FormModel),
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
@@ -3,13 +3,15 @@ package services
 import (
 	"fomrs/internal/db/mongo/answers"
 	"fomrs/internal/db/mongo/forms"
+	"fomrs/internal/db/mongo/revisions"
 )
 
 type FormsService struct {
-	formsRepository   *forms.FormsMongoRepository
-	answersRepository *answers.AnswersMongoRepository
+	formsRepository     *forms.FormsMongoRepository
+	answersRepository   *answers.AnswersMongoRepository
+	revisionsRepository *revisions.RevisionsMongoRepository
 }
 
-func NewFormsService(formsRepository *forms.FormsMongoRepository, answersRepository *answers.AnswersMongoRepository) *FormsService {
-	return &FormsService{formsRepository: formsRepository, answersRepository: answersRepository}
+func NewFormsService(formsRepository *forms.FormsMongoRepository, answersRepository *answers.AnswersMongoRepository, revisionsRepository *revisions.RevisionsMongoRepository) *FormsService {
+	return &FormsService{formsRepository: formsRepository, answersRepository: answersRepository, revisionsRepository: revisionsRepository}
 }
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"fmt"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/forms"
+	"net/http"
+	"time"
+)
+
+// Transition cambia el estado del formulario respetando FormStatusTransitions.
+// El cambio de estado no genera una nueva revisión del contenido.
+func (s *FormsService) Transition(cc *customctx.CustomContext, id string, target entities.FormStatus) utils.Response[forms.FormModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Infof("Changing status of form %s to %s", id, target)
+
+	form := s.formsRepository.Find(cc.Context(), id)
+
+	if form.Err != nil {
+		entry.Error("Error retrieving form", form.Err)
+		return utils.Response[forms.FormModel]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error:      form.Err,
+		}
+	}
+
+	current := form.Data.CurrentStatus()
+
+	if !current.CanTransitionTo(target) {
+		entry.Errorf("Invalid status transition from %s to %s", current, target)
+		return utils.Response[forms.FormModel]{
+			StatusCode: http.StatusConflict,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusConflict,
+					fmt.Sprintf("Form cannot change from %s to %s", current, target),
+					"forms.status.invalid_transition",
+				),
+			),
+		}
+	}
+
+	updated := s.formsRepository.UpdateFields(cc.Context(), id, map[string]interface{}{
+		"status":     target,
+		"updated_at": time.Now().UTC(),
+	})
+
+	if updated.Err != nil {
+		entry.Error("Error updating form status", updated.Err)
+		return utils.Response[forms.FormModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error:      cc.NewError(updated.Err),
+		}
+	}
+
+	return utils.Response[forms.FormModel]{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Data:       updated.Data,
+	}
+}
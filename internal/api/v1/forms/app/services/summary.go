@@ -0,0 +1,462 @@
+package services
+
+import (
+	"common/domain/criteria"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"fomrs/internal/api/v1/forms/domain/commands"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/answers"
+	"fomrs/internal/db/mongo/forms"
+	utils_internal "fomrs/internal/utils"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// Summary devuelve los agregados de las respuestas de un formulario. Todos se
+// calculan con pipelines de agregación sobre la colección de respuestas, sin
+// cargar las respuestas en memoria.
+func (s *FormsService) Summary(cc *customctx.CustomContext, id string, command commands.SummaryCommand) utils.Response[entities.FormSummary] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Summarizing answers of form: ", id)
+
+	form := s.formsRepository.Find(cc.Context(), id)
+
+	if form.Err != nil {
+		entry.Error("Error retrieving form", form.Err)
+		return utils.Response[entities.FormSummary]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error:      form.Err,
+		}
+	}
+
+	format := answers.PeriodFormats[command.Interval]
+
+	total := s.answersRepository.Count(cc.Context(), criteria.Criteria{
+		Filters: *criteria.NewFilters([]criteria.Filter{
+			{Field: "form_id", Operator: criteria.OperatorEqual, Value: id},
+			{Field: "deleted_at", Operator: criteria.OperatorExists, Value: false},
+		}),
+	})
+
+	if total.Err != nil {
+		return summaryFailed(cc, "Error counting answers", total.Err)
+	}
+
+	timeline := s.answersRepository.Timeline(cc.Context(), id, format)
+
+	if timeline.Err != nil {
+		return summaryFailed(cc, "Error aggregating answers timeline", timeline.Err)
+	}
+
+	ids := summaryQuestionIDs(form.Data)
+
+	answered := s.answersRepository.CountAnswered(cc.Context(), id, ids.all)
+
+	if answered.Err != nil {
+		return summaryFailed(cc, "Error counting answered questions", answered.Err)
+	}
+
+	values := s.answersRepository.CountValues(cc.Context(), id, ids.values)
+
+	if values.Err != nil {
+		return summaryFailed(cc, "Error counting answer values", values.Err)
+	}
+
+	matrix := s.answersRepository.CountMatrix(cc.Context(), id, ids.matrix)
+
+	if matrix.Err != nil {
+		return summaryFailed(cc, "Error counting matrix answers", matrix.Err)
+	}
+
+	numbers := s.answersRepository.NumberStats(cc.Context(), id, ids.numbers)
+
+	if numbers.Err != nil {
+		return summaryFailed(cc, "Error aggregating numeric answers", numbers.Err)
+	}
+
+	dates := s.answersRepository.DateHistogram(cc.Context(), id, ids.dates, format)
+
+	if dates.Err != nil {
+		return summaryFailed(cc, "Error aggregating date answers", dates.Err)
+	}
+
+	summary := entities.FormSummary{
+		FormID:    id,
+		Responses: total.Data,
+		Interval:  command.Interval,
+		Timeline:  periodCounts(timeline.Data, ""),
+		Questions: make([]entities.QuestionSummary, 0, len(form.Data.Questions)),
+	}
+
+	for _, question := range form.Data.Questions {
+
+		questionSummary := entities.QuestionSummary{
+			QuestionID: question.ID,
+			Title:      question.Title,
+			Type:       question.Type,
+		}
+
+		for _, count := range answered.Data {
+			if count.QuestionID == question.ID {
+				questionSummary.Answered = count.Count
+			}
+		}
+
+		questionType := utils_internal.QuestionType(question.Type)
+
+		switch {
+		case questionType == utils_internal.QuestionTypeBoolean:
+			questionSummary.Boolean = booleanSummary(question.ID, values.Data, questionSummary.Answered)
+
+		case questionType == utils_internal.QuestionTypeMatrix:
+			questionSummary.Matrix = matrixSummary(question, matrix.Data, questionSummary.Answered)
+
+		case isScaleType(questionType):
+			questionSummary.Options = optionCounts(question.ID, scaleOptions(question), values.Data, questionSummary.Answered)
+			questionSummary.Number = numberSummary(question.ID, numbers.Data)
+			if questionType == utils_internal.QuestionTypeNPS {
+				questionSummary.NPS = npsSummary(questionSummary.Options, questionSummary.Answered)
+			}
+
+		case slices.Contains(ids.values, question.ID):
+			options, _, _ := utils_internal.MetadataStrings(question.Metadata, utils_internal.MetadataOptions)
+			questionSummary.Options = optionCounts(question.ID, options, values.Data, questionSummary.Answered)
+
+		case slices.Contains(ids.numbers, question.ID):
+			questionSummary.Number = numberSummary(question.ID, numbers.Data)
+			if questionSummary.Number != nil {
+				res := s.histogram(cc, id, question, *questionSummary.Number, command.Buckets)
+				if res.Error != nil {
+					return utils.Response[entities.FormSummary]{
+						StatusCode: res.StatusCode,
+						Success:    false,
+						Error:      res.Error,
+					}
+				}
+				questionSummary.Number.Histogram = res.Data
+			}
+
+		case slices.Contains(ids.dates, question.ID):
+			questionSummary.Dates = periodCounts(dates.Data, question.ID)
+		}
+
+		summary.Questions = append(summary.Questions, questionSummary)
+	}
+
+	return utils.Response[entities.FormSummary]{
+		Data:       summary,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// histogram reparte las respuestas numéricas de la pregunta en tramos de igual
+// anchura entre el mínimo y el máximo respondidos.
+func (s *FormsService) histogram(cc *customctx.CustomContext, id string, question entities.QuestionEntity, number entities.NumberSummary, buckets int) utils.Response[[]entities.HistogramBucket] {
+
+	integer := utils_internal.QuestionType(question.Type) == utils_internal.QuestionTypeInteger
+	boundaries := histogramBoundaries(number.Min, number.Max, buckets, integer)
+
+	res := s.answersRepository.NumberHistogram(cc.Context(), id, question.ID, boundaries)
+
+	if res.Err != nil {
+		failed := summaryFailed(cc, "Error aggregating numeric histogram", res.Err)
+		return utils.Response[[]entities.HistogramBucket]{
+			StatusCode: failed.StatusCode,
+			Success:    false,
+			Error:      failed.Error,
+		}
+	}
+
+	histogram := make([]entities.HistogramBucket, len(boundaries)-1)
+	for i := range histogram {
+		histogram[i] = entities.HistogramBucket{From: boundaries[i], To: boundaries[i+1]}
+	}
+
+	for _, bucket := range res.Data {
+		// El máximo queda fuera del último tramo [from, to) y cae en el de desbordamiento.
+		i := len(histogram) - 1
+		if !bucket.Overflow {
+			i = slices.Index(boundaries, bucket.Lower)
+		}
+		if i >= 0 && i < len(histogram) {
+			histogram[i].Count += bucket.Count
+		}
+	}
+
+	return utils.Response[[]entities.HistogramBucket]{
+		Data:       histogram,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// questionIDs agrupa las preguntas según el agregado que se calcula para ellas.
+type questionIDs struct {
+	all     []string
+	values  []string
+	matrix  []string
+	numbers []string
+	dates   []string
+}
+
+func summaryQuestionIDs(form forms.FormModel) questionIDs {
+
+	ids := questionIDs{
+		all:     []string{},
+		values:  []string{},
+		matrix:  []string{},
+		numbers: []string{},
+		dates:   []string{},
+	}
+
+	for _, question := range form.Questions {
+
+		ids.all = append(ids.all, question.ID)
+
+		switch questionType := utils_internal.QuestionType(question.Type); questionType {
+		case utils_internal.QuestionTypeRadio,
+			utils_internal.QuestionTypeSelect,
+			utils_internal.QuestionTypeDropdown,
+			utils_internal.QuestionTypeCheckbox,
+			utils_internal.QuestionTypeMultiDropdown,
+			utils_internal.QuestionTypeCountry,
+			utils_internal.QuestionTypeBoolean:
+			ids.values = append(ids.values, question.ID)
+		case utils_internal.QuestionTypeRating,
+			utils_internal.QuestionTypeScale,
+			utils_internal.QuestionTypeNPS:
+			ids.values = append(ids.values, question.ID)
+			ids.numbers = append(ids.numbers, question.ID)
+		case utils_internal.QuestionTypeNumber,
+			utils_internal.QuestionTypeInteger:
+			ids.numbers = append(ids.numbers, question.ID)
+		case utils_internal.QuestionTypeDate,
+			utils_internal.QuestionTypeDateTime:
+			ids.dates = append(ids.dates, question.ID)
+		case utils_internal.QuestionTypeMatrix:
+			ids.matrix = append(ids.matrix, question.ID)
+		}
+	}
+
+	return ids
+}
+
+func isScaleType(questionType utils_internal.QuestionType) bool {
+	return questionType == utils_internal.QuestionTypeRating ||
+		questionType == utils_internal.QuestionTypeScale ||
+		questionType == utils_internal.QuestionTypeNPS
+}
+
+// scaleOptions devuelve los puntos de la escala para listarlos aunque nadie
+// los haya elegido.
+func scaleOptions(question entities.QuestionEntity) []string {
+
+	validator, err := utils_internal.NewValidator(utils_internal.QuestionType(question.Type), question.Metadata)
+	if err != nil {
+		return nil
+	}
+
+	if rules, ok := validator.(utils_internal.RulesValidator); ok {
+		validator = rules.Validator
+	}
+
+	scale, ok := validator.(utils_internal.ScaleValidator)
+	if !ok {
+		return nil
+	}
+
+	step := max(scale.Step, 1)
+
+	options := []string{}
+	for point := scale.Min; point <= scale.Max; point += step {
+		options = append(options, strconv.Itoa(point))
+	}
+	return options
+}
+
+// optionCounts lista primero las opciones configuradas, en su orden, y después
+// los valores respondidos que ya no están entre ellas.
+func optionCounts(questionID string, options []string, values []answers.ValueCount, answered int64) []entities.OptionCount {
+
+	counts := make([]entities.OptionCount, 0, len(options))
+	for _, option := range options {
+		counts = append(counts, entities.OptionCount{Value: option})
+	}
+
+	for _, value := range values {
+		if value.QuestionID != questionID {
+			continue
+		}
+		i := slices.IndexFunc(counts, func(count entities.OptionCount) bool { return count.Value == value.Value })
+		if i < 0 {
+			counts = append(counts, entities.OptionCount{Value: value.Value})
+			i = len(counts) - 1
+		}
+		counts[i].Count += value.Count
+	}
+
+	// Sin opciones configuradas (p. ej. country) se ordena por frecuencia.
+	if len(options) == 0 {
+		slices.SortStableFunc(counts, func(a, b entities.OptionCount) int {
+			return int(b.Count - a.Count)
+		})
+	}
+
+	for i := range counts {
+		counts[i].Percentage = percentage(counts[i].Count, answered)
+	}
+
+	return counts
+}
+
+func booleanSummary(questionID string, values []answers.ValueCount, answered int64) *entities.BooleanSummary {
+
+	summary := &entities.BooleanSummary{}
+
+	for _, value := range values {
+		if value.QuestionID != questionID {
+			continue
+		}
+		switch value.Value {
+		case "true":
+			summary.True += value.Count
+		case "false":
+			summary.False += value.Count
+		}
+	}
+
+	summary.TruePercentage = percentage(summary.True, answered)
+	summary.FalsePercentage = percentage(summary.False, answered)
+
+	return summary
+}
+
+func matrixSummary(question entities.QuestionEntity, counts []answers.MatrixCount, answered int64) []entities.MatrixRowSummary {
+
+	rows, _, _ := utils_internal.MetadataStrings(question.Metadata, utils_internal.MetadataRows)
+	columns, _, _ := utils_internal.MetadataStrings(question.Metadata, utils_internal.MetadataColumns)
+
+	summary := []entities.MatrixRowSummary{}
+
+	for _, row := range rows {
+		values := []answers.ValueCount{}
+		for _, count := range counts {
+			if count.QuestionID == question.ID && count.Row == row {
+				values = append(values, answers.ValueCount{QuestionID: question.ID, Value: count.Column, Count: count.Count})
+			}
+		}
+		summary = append(summary, entities.MatrixRowSummary{
+			Row:     row,
+			Columns: optionCounts(question.ID, columns, values, answered),
+		})
+	}
+
+	return summary
+}
+
+func numberSummary(questionID string, stats []answers.NumberStats) *entities.NumberSummary {
+
+	i := slices.IndexFunc(stats, func(stat answers.NumberStats) bool { return stat.QuestionID == questionID })
+	if i < 0 {
+		return nil
+	}
+
+	return &entities.NumberSummary{
+		Min:     stats[i].Min,
+		Max:     stats[i].Max,
+		Average: math.Round(stats[i].Avg*100) / 100,
+	}
+}
+
+// npsSummary calcula el Net Promoter Score a partir del recuento de cada punto.
+func npsSummary(options []entities.OptionCount, answered int64) *entities.NPSSummary {
+
+	summary := &entities.NPSSummary{}
+
+	for _, option := range options {
+		point, err := strconv.Atoi(option.Value)
+		if err != nil {
+			continue
+		}
+		switch {
+		case point >= 9:
+			summary.Promoters += option.Count
+		case point >= 7:
+			summary.Passives += option.Count
+		default:
+			summary.Detractors += option.Count
+		}
+	}
+
+	summary.Score = math.Round((percentage(summary.Promoters, answered)-percentage(summary.Detractors, answered))*100) / 100
+
+	return summary
+}
+
+// histogramBoundaries calcula los límites de buckets tramos de igual anchura.
+// En integer la anchura es entera y el último límite queda por encima del máximo.
+func histogramBoundaries(minimum float64, maximum float64, buckets int, integer bool) []float64 {
+
+	if minimum == maximum {
+		return []float64{minimum, minimum + 1}
+	}
+
+	if integer {
+		width := math.Ceil((maximum - minimum + 1) / float64(buckets))
+		count := int(math.Ceil((maximum - minimum + 1) / width))
+		boundaries := make([]float64, count+1)
+		for i := range boundaries {
+			boundaries[i] = minimum + float64(i)*width
+		}
+		return boundaries
+	}
+
+	width := (maximum - minimum) / float64(buckets)
+	boundaries := make([]float64, buckets+1)
+	for i := range boundaries {
+		boundaries[i] = minimum + float64(i)*width
+	}
+	boundaries[buckets] = maximum
+
+	return boundaries
+}
+
+// periodCounts devuelve los periodos de la pregunta; con questionID vacío, los
+// de la evolución de las respuestas.
+func periodCounts(counts []answers.PeriodCount, questionID string) []entities.PeriodCount {
+
+	periods := []entities.PeriodCount{}
+	for _, count := range counts {
+		if count.QuestionID == questionID {
+			periods = append(periods, entities.PeriodCount{Period: count.Period, Count: count.Count})
+		}
+	}
+	return periods
+}
+
+func percentage(count int64, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(count)*10000/float64(total)) / 100
+}
+
+func summaryFailed(cc *customctx.CustomContext, message string, err cerrs.CustomErrorInterface) utils.Response[entities.FormSummary] {
+
+	logger.FromContext(cc.Context()).Error(message, err)
+
+	return utils.Response[entities.FormSummary]{
+		StatusCode: http.StatusInternalServerError,
+		Success:    false,
+		Error:      cc.NewError(err),
+	}
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"common/domain/criteria"
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/utils"
+	"common/utils/cerrs"
+	"fomrs/internal/api/v1/forms/domain/commands"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/forms"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Update reemplaza el contenido del formulario y publica una nueva revisión.
+// Las revisiones anteriores no se modifican, de modo que las respuestas
+// existentes siguen apuntando a la versión con la que fueron validadas.
+func (s *FormsService) Update(cc *customctx.CustomContext, id string, command commands.UpdateFormCommand) utils.Response[forms.FormModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	entry.Info("Updating form id: ", id)
+
+	form := s.findEditableForm(cc, id)
+
+	if form.Error != nil {
+		return form
+	}
+
+	updates := map[string]interface{}{
+		"title":       command.Title,
+		"description": command.Description,
+		"questions":   buildQuestions(command.Questions),
+		"sections":    entities.NormalizeSections(command.Sections),
+		"opens_at":    command.OpensAt,
+		"closes_at":   command.ClosesAt,
+		"settings":    command.Settings,
+	}
+
+	if command.IsTemplate != nil {
+		updates["is_template"] = *command.IsTemplate
+	}
+
+	return s.publishRevision(cc, form.Data, updates)
+}
+
+// findEditableForm obtiene el formulario y comprueba que admita cambios de
+// contenido (los archivados no se editan).
+func (s *FormsService) findEditableForm(cc *customctx.CustomContext, id string) utils.Response[forms.FormModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	form := s.formsRepository.Find(cc.Context(), id)
+
+	if form.Err != nil {
+		entry.Error("Error retrieving form", form.Err)
+		return utils.Response[forms.FormModel]{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Error:      form.Err,
+		}
+	}
+
+	if form.Data.CurrentStatus() == entities.FormStatusArchived {
+		entry.Error("Archived forms cannot be edited")
+		return utils.Response[forms.FormModel]{
+			StatusCode: http.StatusConflict,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(
+					http.StatusConflict,
+					"Archived forms cannot be edited",
+					"forms.update.archived",
+				),
+			),
+		}
+	}
+
+	return utils.Response[forms.FormModel]{
+		Data:       form.Data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// publishRevision aplica los cambios sobre el formulario incrementando su
+// versión. La copia inmutable de la nueva revisión se guarda antes: el índice
+// único (form_id, version) y la condición sobre la versión leída hacen que,
+// entre dos ediciones concurrentes, solo una publique y la otra reciba 409.
+func (s *FormsService) publishRevision(cc *customctx.CustomContext, current forms.FormModel, updates map[string]interface{}) utils.Response[forms.FormModel] {
+
+	entry := logger.FromContext(cc.Context())
+
+	// Los formularios anteriores al versionado no tienen su revisión 1 guardada;
+	// si otra edición ya la guardó, el índice único la rechaza y se sigue.
+	if current.Version < 1 {
+		if res := s.saveRevision(cc, current); res.Err != nil && res.Err.GetCode() != http.StatusConflict {
+			return utils.Response[forms.FormModel]{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Error:      cc.NewError(res.Err),
+			}
+		}
+	}
+
+	updates["version"] = current.CurrentVersion() + 1
+	updates["updated_at"] = time.Now().UTC()
+
+	next, err := withUpdates(current, updates)
+	if err != nil {
+		entry.Error("Error applying form updates", err)
+		return utils.Response[forms.FormModel]{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Error: cc.NewError(
+				cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "forms.update.revision"),
+			),
+		}
+	}
+
+	if res := s.saveRevision(cc, next); res.Err != nil {
+		return utils.Response[forms.FormModel]{
+			StatusCode: res.Err.GetCode(),
+			Success:    false,
+			Error:      cc.NewError(versionConflict(res.Err)),
+		}
+	}
+
+	updated := s.formsRepository.UpdateFieldsIf(cc.Context(), current.ID, sameVersion(current), updates)
+
+	if updated.Err != nil {
+		entry.Error("Error updating form", updated.Err)
+
+		// La revisión guardada no llegó a publicarse.
+		if err := s.revisionsRepository.DeleteVersion(cc.Context(), current.ID, next.Version); err != nil {
+			entry.Error("Error deleting unpublished form revision", err)
+		}
+
+		return utils.Response[forms.FormModel]{
+			StatusCode: updated.Err.GetCode(),
+			Success:    false,
+			Error:      cc.NewError(versionConflict(updated.Err)),
+		}
+	}
+
+	return utils.Response[forms.FormModel]{
+		Data:       updated.Data,
+		StatusCode: http.StatusOK,
+		Success:    true,
+	}
+}
+
+// sameVersion es la condición de que el formulario siga en la versión leída.
+func sameVersion(current forms.FormModel) criteria.Expression {
+	if current.Version < 1 {
+		return criteria.Or(
+			criteria.Where("version", criteria.OperatorExists, false),
+			criteria.Where("version", criteria.OperatorLessThan, 1),
+		)
+	}
+	return criteria.Where("version", criteria.OperatorEqual, current.Version)
+}
+
+// versionConflict traduce los 409 de Mongo a un error de edición concurrente.
+func versionConflict(err cerrs.CustomErrorInterface) cerrs.CustomErrorInterface {
+	if err.GetCode() != http.StatusConflict {
+		return err
+	}
+	return cerrs.NewCustomError(
+		http.StatusConflict,
+		"The form was modified by another request, reload it and try again",
+		"forms.update.version_conflict",
+	)
+}
+
+// withUpdates devuelve una copia del formulario con los cambios aplicados tal
+// como los aplica $set sobre el documento guardado.
+func withUpdates(form forms.FormModel, updates map[string]interface{}) (forms.FormModel, error) {
+
+	var next forms.FormModel
+
+	raw, err := bson.Marshal(form)
+	if err != nil {
+		return next, err
+	}
+
+	var document bson.M
+	if err := bson.Unmarshal(raw, &document); err != nil {
+		return next, err
+	}
+
+	for field, value := range updates {
+		document[field] = value
+	}
+
+	raw, err = bson.Marshal(document)
+	if err != nil {
+		return next, err
+	}
+
+	err = bson.Unmarshal(raw, &next)
+	return next, err
+}
@@ -0,0 +1,23 @@
+package commands
+
+import "common/domain/criteria"
+
+// ListAnswersCommand describe la página de respuestas a consultar.
+type ListAnswersCommand struct {
+	Criteria   criteria.Criteria
+	Cursor     string
+	QuestionID string
+	Value      string
+}
+
+// ExportAnswersCommand describe la exportación de las respuestas de un
+// formulario; admite los mismos filtros que el listado.
+type ExportAnswersCommand struct {
+	Criteria   criteria.Criteria
+	QuestionID string
+	Value      string
+	Format     string
+	// SplitOptions exporta las preguntas de varios valores con una columna
+	// por opción en lugar de unir los valores en una sola.
+	SplitOptions bool
+}
@@ -0,0 +1,11 @@
+package commands
+
+type CloneFormCommand struct {
+	// Title reemplaza el título de la copia; vacío conserva el original.
+	Title string `json:"title"`
+}
+
+type InstantiateTemplateCommand struct {
+	Title      string            `json:"title"`
+	Parameters map[string]string `json:"parameters"`
+}
@@ -1,31 +1,51 @@
 package commands
 
-import "fomrs/internal/api/v1/forms/domain/entities"
+import (
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"time"
+)
 
 type QuestionCommand struct {
-	Title       string         `json:"title" binding:"required"`
-	Description string         `json:"description" binding:"required"`
-	Type        string         `json:"type" binding:"required"`
-	Required    bool           `json:"required" binding:"required"`
-	Section     string         `json:"section"`
-	Metadata    map[string]any `json:"metadata"`
+	ID          string              `json:"id"`
+	Title       string              `json:"title" binding:"required"`
+	Description string              `json:"description" binding:"required"`
+	Type        string              `json:"type" binding:"required"`
+	Required    bool                `json:"required" binding:"required"`
+	Section     string              `json:"section"`
+	Order       int                 `json:"order"`
+	Metadata    map[string]any      `json:"metadata"`
+	VisibleIf   *entities.Condition `json:"visible_if"`
+
+	Scoring *entities.QuestionScoring `json:"scoring"`
 }
 
 func (c QuestionCommand) ToEntity() entities.QuestionEntity {
 	return entities.QuestionEntity{
+		ID:          c.ID,
 		Title:       c.Title,
 		Description: c.Description,
 		Type:        c.Type,
 		Required:    c.Required,
 		Section:     c.Section,
+		Order:       c.Order,
 		Metadata:    c.Metadata,
+		VisibleIf:   c.VisibleIf,
+		Scoring:     c.Scoring,
 	}
 }
 
 type CreateFormCommand struct {
-	Title       string            `json:"title" binding:"required"`
-	Description string            `json:"description" binding:"required"`
-	Questions   []QuestionCommand `json:"questions" binding:"required"`
+	Title       string                   `json:"title" binding:"required"`
+	Description string                   `json:"description" binding:"required"`
+	Questions   []QuestionCommand        `json:"questions" binding:"required"`
+	Sections    []entities.SectionEntity `json:"sections"`
+	OpensAt     *time.Time               `json:"opens_at"`
+	ClosesAt    *time.Time               `json:"closes_at"`
+	Settings    entities.FormSettings    `json:"settings"`
+	IsTemplate  bool                     `json:"is_template"`
+
+	// Source se rellena al clonar o instanciar una plantilla.
+	Source *entities.FormSource `json:"-"`
 }
 
 func (c CreateFormCommand) Validate() error {
@@ -0,0 +1,13 @@
+package commands
+
+// ReorderSectionsCommand fija el orden de todas las secciones del formulario.
+type ReorderSectionsCommand struct {
+	SectionIDs []string `json:"section_ids"`
+}
+
+// ReorderQuestionsCommand fija el orden de las preguntas de una sección; un
+// section_id vacío corresponde a las preguntas sin sección.
+type ReorderQuestionsCommand struct {
+	SectionID   string   `json:"section_id"`
+	QuestionIDs []string `json:"question_ids"`
+}
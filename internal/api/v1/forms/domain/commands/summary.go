@@ -0,0 +1,9 @@
+package commands
+
+// SummaryCommand configura el resumen de respuestas de un formulario.
+type SummaryCommand struct {
+	// Interval agrupa la evolución de las respuestas y las preguntas de fecha.
+	Interval string
+	// Buckets es el número de tramos de los histogramas numéricos.
+	Buckets int
+}
@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"time"
+)
+
+type UpdateFormCommand struct {
+	Title       string                   `json:"title" binding:"required"`
+	Description string                   `json:"description" binding:"required"`
+	Questions   []QuestionCommand        `json:"questions" binding:"required"`
+	Sections    []entities.SectionEntity `json:"sections"`
+	OpensAt     *time.Time               `json:"opens_at"`
+	ClosesAt    *time.Time               `json:"closes_at"`
+	Settings    entities.FormSettings    `json:"settings"`
+	IsTemplate  *bool                    `json:"is_template"`
+}
+
+func (c UpdateFormCommand) Validate() error {
+	return nil
+}
@@ -0,0 +1,295 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type ConditionOperator string
+
+const (
+	ConditionEquals    ConditionOperator = "equals"
+	ConditionNotEquals ConditionOperator = "not_equals"
+	ConditionIn        ConditionOperator = "in"
+	ConditionNotIn     ConditionOperator = "not_in"
+	ConditionNotEmpty  ConditionOperator = "not_empty"
+	ConditionEmpty     ConditionOperator = "empty"
+)
+
+var ConditionOperators = []ConditionOperator{
+	ConditionEquals,
+	ConditionNotEquals,
+	ConditionIn,
+	ConditionNotIn,
+	ConditionNotEmpty,
+	ConditionEmpty,
+}
+
+// Condition decide si una pregunta o sección es visible en función de las
+// respuestas a otras preguntas. Es una hoja (question_id + operator) o una
+// combinación de condiciones con all (y) o any (o).
+type Condition struct {
+	QuestionID string            `json:"question_id,omitempty" bson:"question_id,omitempty"`
+	Operator   ConditionOperator `json:"operator,omitempty" bson:"operator,omitempty"`
+	Value      string            `json:"value,omitempty" bson:"value,omitempty"`
+	Values     []string          `json:"values,omitempty" bson:"values,omitempty"`
+	All        []Condition       `json:"all,omitempty" bson:"all,omitempty"`
+	Any        []Condition       `json:"any,omitempty" bson:"any,omitempty"`
+}
+
+// IsLeaf indica si la condición compara directamente una pregunta.
+func (c Condition) IsLeaf() bool {
+	return len(c.All) == 0 && len(c.Any) == 0
+}
+
+// Validate comprueba la estructura de la condición (no las referencias).
+func (c Condition) Validate() error {
+
+	if !c.IsLeaf() {
+		if c.QuestionID != "" || c.Operator != "" {
+			return errors.New("condition must be either a comparison or a combination with all/any")
+		}
+		if len(c.All) > 0 && len(c.Any) > 0 {
+			return errors.New("condition cannot declare all and any at the same time")
+		}
+		for _, child := range append(slices.Clone(c.All), c.Any...) {
+			if err := child.Validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if c.QuestionID == "" {
+		return errors.New("condition question_id is required")
+	}
+
+	switch c.Operator {
+	case ConditionEquals, ConditionNotEquals:
+		if c.Value == "" {
+			return fmt.Errorf("condition operator %s requires value", c.Operator)
+		}
+	case ConditionIn, ConditionNotIn:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("condition operator %s requires values", c.Operator)
+		}
+	case ConditionNotEmpty, ConditionEmpty:
+	default:
+		return errors.New("invalid condition operator: " + string(c.Operator))
+	}
+
+	return nil
+}
+
+// References devuelve los ids de las preguntas de las que depende la condición.
+func (c Condition) References() []string {
+
+	if c.IsLeaf() {
+		return []string{c.QuestionID}
+	}
+
+	var refs []string
+	for _, child := range append(slices.Clone(c.All), c.Any...) {
+		refs = append(refs, child.References()...)
+	}
+	return refs
+}
+
+// Evaluate evalúa la condición; answers devuelve los valores respondidos a una
+// pregunta (vacío si no se respondió o está oculta).
+func (c Condition) Evaluate(answers func(questionID string) []string) bool {
+
+	switch {
+	case len(c.All) > 0:
+		for _, child := range c.All {
+			if !child.Evaluate(answers) {
+				return false
+			}
+		}
+		return true
+	case len(c.Any) > 0:
+		for _, child := range c.Any {
+			if child.Evaluate(answers) {
+				return true
+			}
+		}
+		return false
+	}
+
+	values := answers(c.QuestionID)
+
+	switch c.Operator {
+	case ConditionEquals:
+		return slices.Contains(values, c.Value)
+	case ConditionNotEquals:
+		return !slices.Contains(values, c.Value)
+	case ConditionIn:
+		return slices.ContainsFunc(values, func(v string) bool { return slices.Contains(c.Values, v) })
+	case ConditionNotIn:
+		return !slices.ContainsFunc(values, func(v string) bool { return slices.Contains(c.Values, v) })
+	case ConditionNotEmpty:
+		return slices.ContainsFunc(values, func(v string) bool { return strings.TrimSpace(v) != "" })
+	case ConditionEmpty:
+		return !slices.ContainsFunc(values, func(v string) bool { return strings.TrimSpace(v) != "" })
+	}
+
+	return false
+}
+
+// ValidateConditions comprueba que las condiciones de preguntas y secciones
+// estén bien formadas, referencien preguntas existentes y no formen ciclos.
+func ValidateConditions(questions []QuestionEntity, sections []SectionEntity) error {
+
+	known := make(map[string]bool, len(questions))
+	for _, question := range questions {
+		if question.ID != "" {
+			known[question.ID] = true
+		}
+	}
+
+	check := func(owner string, condition *Condition) error {
+		if condition == nil {
+			return nil
+		}
+		if err := condition.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", owner, err)
+		}
+		for _, ref := range condition.References() {
+			if !known[ref] {
+				return fmt.Errorf("%s: condition references unknown question id %s", owner, ref)
+			}
+		}
+		return nil
+	}
+
+	for _, section := range sections {
+		if err := check("section "+section.ID, section.VisibleIf); err != nil {
+			return err
+		}
+	}
+
+	for _, question := range questions {
+		if err := check("question "+question.Title, question.VisibleIf); err != nil {
+			return err
+		}
+	}
+
+	return detectCycles(questions, sections)
+}
+
+// dependencies devuelve, para cada pregunta, las preguntas de las que depende
+// su visibilidad (su propia condición y la de su sección).
+func dependencies(questions []QuestionEntity, sections []SectionEntity) map[string][]string {
+
+	sectionConditions := make(map[string]*Condition, len(sections))
+	for _, section := range sections {
+		sectionConditions[section.ID] = section.VisibleIf
+	}
+
+	deps := make(map[string][]string, len(questions))
+	for _, question := range questions {
+		if question.VisibleIf != nil {
+			deps[question.ID] = append(deps[question.ID], question.VisibleIf.References()...)
+		}
+		if condition := sectionConditions[question.Section]; condition != nil {
+			deps[question.ID] = append(deps[question.ID], condition.References()...)
+		}
+	}
+	return deps
+}
+
+func detectCycles(questions []QuestionEntity, sections []SectionEntity) error {
+
+	deps := dependencies(questions, sections)
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(questions))
+
+	var visit func(id string, path []string) error
+	visit = func(id string, path []string) error {
+		switch state[id] {
+		case visiting:
+			return errors.New("visibility conditions form a cycle: " + strings.Join(append(path, id), " -> "))
+		case done:
+			return nil
+		}
+		state[id] = visiting
+		for _, dep := range deps[id] {
+			if err := visit(dep, append(path, id)); err != nil {
+				return err
+			}
+		}
+		state[id] = done
+		return nil
+	}
+
+	for _, question := range questions {
+		if err := visit(question.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Visibility calcula qué preguntas son visibles para un conjunto de respuestas.
+// Las respuestas a preguntas ocultas se ignoran al evaluar otras condiciones.
+func Visibility(questions []QuestionEntity, sections []SectionEntity, answers map[string][]string) map[string]bool {
+
+	byID := make(map[string]QuestionEntity, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+
+	sectionConditions := make(map[string]*Condition, len(sections))
+	for _, section := range sections {
+		sectionConditions[section.ID] = section.VisibleIf
+	}
+
+	visible := make(map[string]bool, len(questions))
+	resolving := make(map[string]bool)
+
+	var isVisible func(id string) bool
+	isVisible = func(id string) bool {
+
+		if v, ok := visible[id]; ok {
+			return v
+		}
+
+		question, ok := byID[id]
+		// Un ciclo no debería existir (se valida al crear); por seguridad se
+		// considera oculta.
+		if !ok || resolving[id] {
+			return false
+		}
+		resolving[id] = true
+
+		lookup := func(ref string) []string {
+			if !isVisible(ref) {
+				return nil
+			}
+			return answers[ref]
+		}
+
+		result := true
+		if condition := sectionConditions[question.Section]; condition != nil && !condition.Evaluate(lookup) {
+			result = false
+		}
+		if result && question.VisibleIf != nil && !question.VisibleIf.Evaluate(lookup) {
+			result = false
+		}
+
+		delete(resolving, id)
+		visible[id] = result
+		return result
+	}
+
+	for _, question := range questions {
+		isVisible(question.ID)
+	}
+	return visible
+}
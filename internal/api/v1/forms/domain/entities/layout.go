@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"slices"
+)
+
+// NormalizeSections ordena las secciones por Order (a igual Order se respeta
+// el orden recibido) y reasigna Order consecutivo empezando en 1. Las
+// secciones sin título usan su id.
+func NormalizeSections(sections []SectionEntity) []SectionEntity {
+
+	normalized := slices.Clone(sections)
+
+	slices.SortStableFunc(normalized, func(a, b SectionEntity) int {
+		return a.Order - b.Order
+	})
+
+	for i := range normalized {
+		normalized[i].Order = i + 1
+		if normalized[i].Title == "" {
+			normalized[i].Title = normalized[i].ID
+		}
+	}
+	return normalized
+}
+
+// NormalizeQuestions ordena las preguntas dentro de cada sección por Order (a
+// igual Order se respeta el orden recibido) y reasigna Order consecutivo por
+// sección empezando en 1.
+func NormalizeQuestions(questions []QuestionEntity) []QuestionEntity {
+
+	normalized := slices.Clone(questions)
+
+	slices.SortStableFunc(normalized, func(a, b QuestionEntity) int {
+		return a.Order - b.Order
+	})
+
+	next := make(map[string]int)
+	for i := range normalized {
+		next[normalized[i].Section]++
+		normalized[i].Order = next[normalized[i].Section]
+	}
+	return normalized
+}
+
+// Layout agrupa las preguntas en sus secciones. Las secciones que las
+// preguntas referencian pero no están declaradas (formularios anteriores a
+// las secciones) se añaden al final en orden de aparición, con el id como
+// título; las preguntas sin sección quedan en un grupo con id vacío al final.
+func Layout(questions []QuestionEntity, sections []SectionEntity) []SectionLayout {
+
+	sections = LayoutSections(questions, sections)
+
+	layout := make([]SectionLayout, len(sections))
+	index := make(map[string]int, len(sections))
+
+	for i, section := range sections {
+		layout[i] = SectionLayout{SectionEntity: section, Questions: []QuestionEntity{}}
+		index[section.ID] = i
+	}
+
+	for _, question := range questions {
+		i := index[question.Section]
+		layout[i].Questions = append(layout[i].Questions, question)
+	}
+
+	for i := range layout {
+		slices.SortStableFunc(layout[i].Questions, func(a, b QuestionEntity) int {
+			return a.Order - b.Order
+		})
+	}
+
+	return layout
+}
+
+// LayoutSections devuelve las secciones declaradas ordenadas más las
+// implícitas que referencian las preguntas.
+func LayoutSections(questions []QuestionEntity, sections []SectionEntity) []SectionEntity {
+
+	result := slices.Clone(sections)
+
+	slices.SortStableFunc(result, func(a, b SectionEntity) int {
+		return a.Order - b.Order
+	})
+
+	known := make(map[string]bool, len(result))
+	for _, section := range result {
+		known[section.ID] = true
+	}
+
+	unsectioned := false
+
+	for _, question := range questions {
+		if question.Section == "" {
+			unsectioned = true
+			continue
+		}
+		if known[question.Section] {
+			continue
+		}
+		known[question.Section] = true
+		result = append(result, SectionEntity{
+			ID:    question.Section,
+			Title: question.Section,
+			Order: len(result) + 1,
+		})
+	}
+
+	if unsectioned {
+		result = append(result, SectionEntity{Order: len(result) + 1})
+	}
+
+	return result
+}
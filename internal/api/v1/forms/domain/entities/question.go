@@ -7,5 +7,10 @@ type QuestionEntity struct {
 	Type        string         `json:"type" bson:"type"`
 	Required    bool           `json:"required" bson:"required"`
 	Section     string         `json:"section" bson:"section"`
+	Order       int            `json:"order" bson:"order"`
 	Metadata    map[string]any `json:"metadata" bson:"metadata"`
+	VisibleIf   *Condition     `json:"visible_if,omitempty" bson:"visible_if,omitempty"`
+
+	// Scoring marca la pregunta como puntuable en formularios con quiz.
+	Scoring *QuestionScoring `json:"scoring,omitempty" bson:"scoring,omitempty"`
 }
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+)
+
+// QuestionScoring convierte una pregunta en puntuable dentro de un quiz.
+type QuestionScoring struct {
+	// CorrectAnswers son las respuestas correctas. En preguntas de un solo
+	// valor basta con acertar una; en las de varios valores hay que marcar
+	// todas (y solo esas). En matrices cada entrada es "fila:columna".
+	CorrectAnswers []string `json:"correct_answers" bson:"correct_answers"`
+	// Points es lo que vale la pregunta acertada.
+	Points float64 `json:"points" bson:"points"`
+	// PartialCredit reparte los puntos en preguntas de varios valores: cada
+	// acierto suma y cada opción incorrecta resta, sin bajar de 0.
+	PartialCredit bool `json:"partial_credit" bson:"partial_credit"`
+}
+
+func (s QuestionScoring) Validate() error {
+
+	if s.Points <= 0 {
+		return errors.New("scoring.points must be greater than 0")
+	}
+
+	if len(s.CorrectAnswers) == 0 {
+		return errors.New("scoring.correct_answers must not be empty")
+	}
+
+	seen := make(map[string]bool, len(s.CorrectAnswers))
+	for _, answer := range s.CorrectAnswers {
+		if strings.TrimSpace(answer) == "" || seen[answer] {
+			return errors.New("scoring.correct_answers must not contain empty or repeated values")
+		}
+		seen[answer] = true
+	}
+
+	return nil
+}
+
+// QuizSettings activa la puntuación de las respuestas.
+type QuizSettings struct {
+	Enabled bool `json:"enabled" bson:"enabled"`
+	// PassThreshold es el porcentaje (0-100) de puntos necesario para aprobar.
+	PassThreshold float64 `json:"pass_threshold" bson:"pass_threshold"`
+	// RevealAnswers incluye las respuestas correctas en la respuesta del envío.
+	RevealAnswers bool `json:"reveal_answers" bson:"reveal_answers"`
+}
+
+func (q QuizSettings) Validate() error {
+
+	if q.PassThreshold < 0 || q.PassThreshold > 100 {
+		return errors.New("quiz.pass_threshold must be between 0 and 100")
+	}
+
+	return nil
+}
+
+// WithoutScoring devuelve una copia de las preguntas sin las respuestas
+// correctas, para mostrar el formulario a quien lo responde.
+func WithoutScoring(questions []QuestionEntity) []QuestionEntity {
+
+	stripped := make([]QuestionEntity, len(questions))
+	for i, question := range questions {
+		question.Scoring = nil
+		stripped[i] = question
+	}
+	return stripped
+}
@@ -0,0 +1,17 @@
+package entities
+
+// SectionEntity describe una sección del formulario; las preguntas la
+// referencian por su id en QuestionEntity.Section.
+type SectionEntity struct {
+	ID          string     `json:"id" bson:"id"`
+	Title       string     `json:"title" bson:"title"`
+	Description string     `json:"description" bson:"description"`
+	Order       int        `json:"order" bson:"order"`
+	VisibleIf   *Condition `json:"visible_if,omitempty" bson:"visible_if,omitempty"`
+}
+
+// SectionLayout es una sección con sus preguntas ya ordenadas.
+type SectionLayout struct {
+	SectionEntity
+	Questions []QuestionEntity `json:"questions"`
+}
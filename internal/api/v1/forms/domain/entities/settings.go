@@ -0,0 +1,61 @@
+package entities
+
+import "errors"
+
+// FormSettings agrupa las opciones de comportamiento del formulario.
+type FormSettings struct {
+	// Lenient desactiva el modo estricto al responder: se aceptan preguntas
+	// desconocidas, entradas duplicadas (gana la última) y values en preguntas
+	// de un solo valor.
+	Lenient bool `json:"lenient" bson:"lenient"`
+
+	// AllowEdits permite corregir (PATCH) y eliminar (DELETE) respuestas ya
+	// enviadas mientras el formulario acepte respuestas.
+	AllowEdits bool `json:"allow_edits" bson:"allow_edits"`
+
+	// Submissions limita cuántas respuestas acepta el formulario.
+	Submissions SubmissionPolicy `json:"submissions" bson:"submissions"`
+
+	// Quiz puntúa las respuestas con el scoring de cada pregunta.
+	Quiz QuizSettings `json:"quiz" bson:"quiz"`
+}
+
+// Validate comprueba que las opciones sean coherentes.
+func (s FormSettings) Validate() error {
+	if err := s.Submissions.Validate(); err != nil {
+		return err
+	}
+	return s.Quiz.Validate()
+}
+
+// SubmissionPolicy limita los envíos de respuestas. Los límites a 0 no aplican.
+type SubmissionPolicy struct {
+	// SinglePerUser admite una sola respuesta por user_id.
+	SinglePerUser bool `json:"single_per_user" bson:"single_per_user"`
+	// LatestWins, junto con SinglePerUser, sustituye la respuesta anterior del
+	// usuario en lugar de rechazar el nuevo envío.
+	LatestWins bool `json:"latest_wins" bson:"latest_wins"`
+	// MaxTotal es el máximo de respuestas del formulario.
+	MaxTotal int `json:"max_total" bson:"max_total"`
+	// MaxPerWindow es el máximo de respuestas del formulario en los últimos
+	// WindowSeconds segundos.
+	MaxPerWindow  int `json:"max_per_window" bson:"max_per_window"`
+	WindowSeconds int `json:"window_seconds" bson:"window_seconds"`
+}
+
+func (p SubmissionPolicy) Validate() error {
+
+	if p.LatestWins && !p.SinglePerUser {
+		return errors.New("submissions.latest_wins requires submissions.single_per_user")
+	}
+
+	if p.MaxTotal < 0 || p.MaxPerWindow < 0 || p.WindowSeconds < 0 {
+		return errors.New("submission limits must be greater or equal than 0")
+	}
+
+	if (p.MaxPerWindow > 0) != (p.WindowSeconds > 0) {
+		return errors.New("submissions.max_per_window and submissions.window_seconds must be set together")
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "testing"
+
+func TestSubmissionPolicyValidate(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		policy  SubmissionPolicy
+		wantErr bool
+	}{
+		{name: "no limits", policy: SubmissionPolicy{}},
+		{name: "single per user", policy: SubmissionPolicy{SinglePerUser: true}},
+		{name: "latest wins with single per user", policy: SubmissionPolicy{SinglePerUser: true, LatestWins: true}},
+		{name: "latest wins alone", policy: SubmissionPolicy{LatestWins: true}, wantErr: true},
+		{name: "max total", policy: SubmissionPolicy{MaxTotal: 100}},
+		{name: "negative max total", policy: SubmissionPolicy{MaxTotal: -1}, wantErr: true},
+		{name: "window", policy: SubmissionPolicy{MaxPerWindow: 10, WindowSeconds: 60}},
+		{name: "window without seconds", policy: SubmissionPolicy{MaxPerWindow: 10}, wantErr: true},
+		{name: "seconds without window", policy: SubmissionPolicy{WindowSeconds: 60}, wantErr: true},
+		{name: "negative window", policy: SubmissionPolicy{MaxPerWindow: -1, WindowSeconds: 60}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
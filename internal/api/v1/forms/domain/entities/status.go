@@ -0,0 +1,25 @@
+package entities
+
+import "slices"
+
+// FormStatus representa el estado del ciclo de vida de un formulario.
+type FormStatus string
+
+const (
+	FormStatusDraft     FormStatus = "draft"
+	FormStatusPublished FormStatus = "published"
+	FormStatusClosed    FormStatus = "closed"
+	FormStatusArchived  FormStatus = "archived"
+)
+
+// FormStatusTransitions define a qué estados se puede pasar desde cada estado.
+var FormStatusTransitions = map[FormStatus][]FormStatus{
+	FormStatusDraft:     {FormStatusPublished, FormStatusArchived},
+	FormStatusPublished: {FormStatusClosed, FormStatusArchived},
+	FormStatusClosed:    {FormStatusPublished, FormStatusArchived},
+	FormStatusArchived:  {},
+}
+
+func (s FormStatus) CanTransitionTo(target FormStatus) bool {
+	return slices.Contains(FormStatusTransitions[s], target)
+}
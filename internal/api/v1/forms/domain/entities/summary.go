@@ -0,0 +1,89 @@
+package entities
+
+// Intervalos con los que se agrupan las respuestas y las fechas en el resumen.
+const (
+	SummaryIntervalDay   = "day"
+	SummaryIntervalWeek  = "week"
+	SummaryIntervalMonth = "month"
+	SummaryIntervalYear  = "year"
+)
+
+// FormSummary son los agregados de las respuestas de un formulario.
+type FormSummary struct {
+	FormID    string            `json:"form_id"`
+	Responses int64             `json:"responses"`
+	Interval  string            `json:"interval"`
+	Timeline  []PeriodCount     `json:"timeline"`
+	Questions []QuestionSummary `json:"questions"`
+}
+
+// QuestionSummary resume las respuestas a una pregunta. Solo se rellena el
+// agregado que corresponde a su tipo.
+type QuestionSummary struct {
+	QuestionID string `json:"question_id"`
+	Title      string `json:"title"`
+	Type       string `json:"type"`
+	// Answered es el número de respuestas que contestan la pregunta; es la
+	// base de los porcentajes.
+	Answered int64 `json:"answered"`
+
+	Options []OptionCount      `json:"options,omitempty"`
+	Boolean *BooleanSummary    `json:"boolean,omitempty"`
+	Matrix  []MatrixRowSummary `json:"matrix,omitempty"`
+	Number  *NumberSummary     `json:"number,omitempty"`
+	Dates   []PeriodCount      `json:"dates,omitempty"`
+	NPS     *NPSSummary        `json:"nps,omitempty"`
+}
+
+// OptionCount es el número de respuestas que eligieron un valor.
+type OptionCount struct {
+	Value      string  `json:"value"`
+	Count      int64   `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
+
+// BooleanSummary reparte las respuestas entre true y false.
+type BooleanSummary struct {
+	True            int64   `json:"true"`
+	False           int64   `json:"false"`
+	TruePercentage  float64 `json:"true_percentage"`
+	FalsePercentage float64 `json:"false_percentage"`
+}
+
+// MatrixRowSummary son las columnas elegidas en una fila de la matriz.
+type MatrixRowSummary struct {
+	Row     string        `json:"row"`
+	Columns []OptionCount `json:"columns"`
+}
+
+// NumberSummary resume las respuestas numéricas. El histograma solo se
+// calcula en number e integer.
+type NumberSummary struct {
+	Min       float64           `json:"min"`
+	Max       float64           `json:"max"`
+	Average   float64           `json:"average"`
+	Histogram []HistogramBucket `json:"histogram,omitempty"`
+}
+
+// HistogramBucket es el tramo [From, To) del histograma. En number el último
+// tramo termina en el máximo respondido y lo incluye.
+type HistogramBucket struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int64   `json:"count"`
+}
+
+// PeriodCount es el número de respuestas de un periodo.
+type PeriodCount struct {
+	Period string `json:"period"`
+	Count  int64  `json:"count"`
+}
+
+// NPSSummary clasifica las respuestas NPS: promotores (9-10), pasivos (7-8)
+// y detractores (0-6). Score es % promotores - % detractores.
+type NPSSummary struct {
+	Promoters  int64   `json:"promoters"`
+	Passives   int64   `json:"passives"`
+	Detractors int64   `json:"detractors"`
+	Score      float64 `json:"score"`
+}
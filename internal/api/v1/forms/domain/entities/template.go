@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"regexp"
+	"slices"
+)
+
+// templateParameter reconoce los parámetros {{nombre}} de títulos y
+// descripciones de las plantillas.
+var templateParameter = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// FormSource registra de qué formulario, y en qué versión, se copió otro.
+type FormSource struct {
+	FormID  string `json:"form_id" bson:"form_id"`
+	Version int    `json:"version" bson:"version"`
+	// Template indica que la copia se instanció desde una plantilla.
+	Template bool `json:"template" bson:"template"`
+}
+
+// TemplateParameters devuelve los parámetros usados en los textos, sin
+// repetir y en orden de aparición.
+func TemplateParameters(texts ...string) []string {
+
+	parameters := []string{}
+	for _, text := range texts {
+		for _, match := range templateParameter.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(parameters, match[1]) {
+				parameters = append(parameters, match[1])
+			}
+		}
+	}
+	return parameters
+}
+
+// ReplaceParameters sustituye los parámetros del texto por sus valores; los
+// que no tienen valor se dejan tal cual.
+func ReplaceParameters(text string, values map[string]string) string {
+	return templateParameter.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateParameter.FindStringSubmatch(match)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return match
+	})
+}
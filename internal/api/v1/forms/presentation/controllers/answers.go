@@ -3,6 +3,8 @@ package controllers
 import (
 	"common/domain/customctx"
 	"common/domain/logger"
+	"common/interface/cdtos"
+	"fomrs/internal/api/v1/forms/presentation/dtos"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -25,7 +27,21 @@ func (c *FormsController) Answers(ctx *gin.Context) {
 	}
 	entry.Info("Retrieving answers of form: ", id)
 
-	response := c.formsService.Answers(cc, id)
+	dto := cdtos.GetQueryDTOWithResponse[dtos.ListAnswersDTO](ctx, cc)
+
+	if dto.Error != nil {
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	cri := cdtos.GetCriteriaWithResponse(ctx, cc, dtos.AnswersQuerySchema)
+
+	if cri.Error != nil {
+		ctx.JSON(cri.StatusCode, cri.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := c.formsService.Answers(cc, id, dto.Data.ToCommand(cri.Data))
 
 	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
 
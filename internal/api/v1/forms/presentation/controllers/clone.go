@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"common/utils"
+	"fomrs/internal/api/v1/forms/presentation/dtos"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *FormsController) Clone(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx)
+
+	id := ctx.Param("id")
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+	entry.Info("Cloning form: ", id)
+
+	dto := optionalDTO[dtos.CloneFormDTO](ctx, cc)
+
+	if dto.Error != nil {
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := hideScoring(ctx, c.formsService.Clone(cc, id, dto.Data.ToCommand()))
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *FormsController) Instantiate(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx)
+
+	id := ctx.Param("id")
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+	entry.Info("Instantiating template: ", id)
+
+	dto := optionalDTO[dtos.InstantiateTemplateDTO](ctx, cc)
+
+	if dto.Error != nil {
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := hideScoring(ctx, c.formsService.Instantiate(cc, id, dto.Data.ToCommand()))
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+// optionalDTO lee el cuerpo solo si se envió; sin cuerpo devuelve el DTO vacío.
+func optionalDTO[K cdtos.DTO](ctx *gin.Context, cc *customctx.CustomContext) utils.Response[K] {
+
+	if ctx.Request.ContentLength == 0 {
+		var dto K
+		return utils.Response[K]{
+			Data:       dto,
+			StatusCode: http.StatusOK,
+			Success:    true,
+		}
+	}
+
+	return cdtos.GetDTOWithResponse[K](ctx, cc)
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"fomrs/internal/api/v1/forms/presentation/dtos"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *FormsController) ExportAnswers(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx)
+
+	id := ctx.Param("id")
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+	entry.Info("Exporting answers of form: ", id)
+
+	dto := cdtos.GetQueryDTOWithResponse[dtos.ExportAnswersDTO](ctx, cc)
+
+	if dto.Error != nil {
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	cri := cdtos.GetCriteriaWithResponse(ctx, cc, dtos.ExportAnswersQuerySchema)
+
+	if cri.Error != nil {
+		ctx.JSON(cri.StatusCode, cri.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := c.formsService.ExportAnswers(cc, id, dto.Data.ToCommand(cri.Data))
+
+	if response.Error != nil {
+		ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+		return
+	}
+
+	ctx.Header("Content-Type", response.Data.ContentType)
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": response.Data.Filename}))
+	ctx.Status(http.StatusOK)
+
+	// Las cabeceras ya se enviaron: un fallo a mitad solo puede registrarse.
+	if err := response.Data.Write(ctx.Writer); err != nil {
+		entry.Error("Error exporting answers", err)
+	}
+}
@@ -3,19 +3,36 @@ package controllers
 import (
 	"common/domain/customctx"
 	"common/domain/logger"
+	"common/interface/cdtos"
+	"fomrs/internal/api/v1/forms/presentation/dtos"
 
 	"github.com/gin-gonic/gin"
 )
 
 func (c *FormsController) List(ctx *gin.Context) {
+	c.list(ctx, false)
+}
+
+func (c *FormsController) Templates(ctx *gin.Context) {
+	c.list(ctx, true)
+}
+
+func (c *FormsController) list(ctx *gin.Context, templates bool) {
 
 	entry := logger.FromContext(ctx)
 
-	entry.Info("Listing forms")
+	entry.Info("Listing forms, templates: ", templates)
 
 	cc := customctx.NewCustomContext(ctx)
 
-	response := c.formsService.List(cc)
+	cri := cdtos.GetCriteriaWithResponse(ctx, cc, dtos.FormsQuerySchema)
+
+	if cri.Error != nil {
+		ctx.JSON(cri.StatusCode, cri.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := c.formsService.List(cc, cri.Data, templates)
 
 	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
 }
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"fomrs/internal/api/v1/forms/presentation/dtos"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *FormsController) ReorderSections(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	entry.Info("Reordering form sections")
+
+	cc := customctx.NewCustomContext(ctx)
+
+	id := ctx.Param("id")
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+
+	dto := cdtos.GetDTOWithResponse[dtos.ReorderSectionsDTO](ctx, cc)
+
+	if dto.Error != nil {
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := hideScoring(ctx, c.formsService.ReorderSections(cc, id, dto.Data.ToCommand()))
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
+
+func (c *FormsController) ReorderQuestions(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	entry.Info("Reordering form questions")
+
+	cc := customctx.NewCustomContext(ctx)
+
+	id := ctx.Param("id")
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+
+	dto := cdtos.GetDTOWithResponse[dtos.ReorderQuestionsDTO](ctx, cc)
+
+	if dto.Error != nil {
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := hideScoring(ctx, c.formsService.ReorderQuestions(cc, id, dto.Data.ToCommand()))
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
@@ -3,7 +3,12 @@ package controllers
 import (
 	"common/domain/customctx"
 	"common/domain/logger"
+	"common/interface/cdtos"
+	"common/utils"
+	"crypto/subtle"
+	"fomrs/internal/core/settings"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -26,8 +31,71 @@ func (c *FormsController) Retrieve(ctx *gin.Context) {
 		return
 	}
 
-	response := c.formsService.Retrieve(cc, id)
+	version, ok := queryVersion(ctx)
+	if !ok {
+		return
+	}
+
+	// Las respuestas correctas de los quizzes solo se muestran si se piden y
+	// con el token de administración.
+	includeScoring := ctx.Query("include_scoring") == "true"
+	if includeScoring && !isAdmin(ctx) {
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"error":      "include_scoring requires the admin token",
+			"success":    false,
+			"statusCode": http.StatusForbidden,
+		})
+		return
+	}
+
+	response := c.formsService.Retrieve(cc, id, version, includeScoring)
 
 	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
 
 }
+
+// isAdmin comprueba que el header Authorization traiga el ADMIN_TOKEN.
+func isAdmin(ctx *gin.Context) bool {
+
+	adminToken := settings.Settings.ADMIN_TOKEN
+	if adminToken == "" {
+		return false
+	}
+
+	token := cdtos.GetAuthToken(ctx)
+	if token.Err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token.Data), []byte(adminToken)) == 1
+}
+
+// hideScoring quita las respuestas correctas del formulario devuelto salvo
+// al administrador, con el mismo criterio que include_scoring.
+func hideScoring[T interface{ WithoutScoring() T }](ctx *gin.Context, response utils.Response[T]) utils.Response[T] {
+	if response.Success && !isAdmin(ctx) {
+		response.Data = response.Data.WithoutScoring()
+	}
+	return response
+}
+
+// queryVersion lee el query param version; si no es válido responde 400.
+func queryVersion(ctx *gin.Context) (int, bool) {
+
+	raw := ctx.Query("version")
+	if raw == "" {
+		return 0, true
+	}
+
+	version, err := strconv.Atoi(raw)
+	if err != nil || version < 1 {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "version must be a positive integer",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return 0, false
+	}
+
+	return version, true
+}
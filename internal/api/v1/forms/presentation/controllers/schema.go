@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"encoding/json"
+	"fomrs/internal/api/v1/forms/presentation/dtos"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Schema devuelve el JSON Schema sin el envoltorio habitual de la respuesta
+// para que las herramientas de JSON Schema lo consuman directamente.
+func (c *FormsController) Schema(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx)
+
+	id := ctx.Param("id")
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+	entry.Info("Building JSON Schema of form: ", id)
+
+	version, ok := queryVersion(ctx)
+	if !ok {
+		return
+	}
+
+	response := c.formsService.Schema(cc, id, version)
+
+	if response.Error != nil {
+		ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+		return
+	}
+
+	body, err := json.Marshal(response.Data)
+	if err != nil {
+		entry.Error("Error encoding JSON Schema", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error":      "error encoding JSON Schema",
+			"success":    false,
+			"statusCode": http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/schema+json", body)
+}
+
+func (c *FormsController) Import(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	entry.Info("Importing form from JSON Schema")
+
+	cc := customctx.NewCustomContext(ctx)
+
+	dto := cdtos.GetDTOWithResponse[dtos.ImportFormDTO](ctx, cc)
+
+	if dto.Error != nil {
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := c.formsService.CreateForm(cc, dto.Data.ToCommand())
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
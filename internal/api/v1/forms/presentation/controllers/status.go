@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *FormsController) Publish(ctx *gin.Context) {
+	c.transition(ctx, entities.FormStatusPublished)
+}
+
+func (c *FormsController) Close(ctx *gin.Context) {
+	c.transition(ctx, entities.FormStatusClosed)
+}
+
+func (c *FormsController) Archive(ctx *gin.Context) {
+	c.transition(ctx, entities.FormStatusArchived)
+}
+
+func (c *FormsController) transition(ctx *gin.Context, target entities.FormStatus) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx)
+
+	id := ctx.Param("id")
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+	entry.Infof("Changing status of form %s to %s", id, target)
+
+	response := hideScoring(ctx, c.formsService.Transition(cc, id, target))
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"fomrs/internal/api/v1/forms/presentation/dtos"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *FormsController) Summary(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	cc := customctx.NewCustomContext(ctx)
+
+	id := ctx.Param("id")
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+	entry.Info("Summarizing answers of form: ", id)
+
+	dto := cdtos.GetQueryDTOWithResponse[dtos.SummaryDTO](ctx, cc)
+
+	if dto.Error != nil {
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := c.formsService.Summary(cc, id, dto.Data.ToCommand())
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"common/domain/customctx"
+	"common/domain/logger"
+	"common/interface/cdtos"
+	"fomrs/internal/api/v1/forms/presentation/dtos"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *FormsController) Update(ctx *gin.Context) {
+
+	entry := logger.FromContext(ctx)
+
+	entry.Info("Updating form")
+
+	cc := customctx.NewCustomContext(ctx)
+
+	id := ctx.Param("id")
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      "id is required",
+			"success":    false,
+			"statusCode": http.StatusBadRequest,
+		})
+		return
+	}
+
+	dto := cdtos.GetDTOWithResponse[dtos.UpdateFormDTO](ctx, cc)
+
+	if dto.Error != nil {
+		ctx.JSON(dto.StatusCode, dto.ToMapWithCustomContext(cc))
+		return
+	}
+
+	response := hideScoring(ctx, c.formsService.Update(cc, id, dto.Data.ToCommand()))
+
+	ctx.JSON(response.StatusCode, response.ToMapWithCustomContext(cc))
+}
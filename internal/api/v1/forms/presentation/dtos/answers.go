@@ -0,0 +1,33 @@
+package dtos
+
+import (
+	"common/domain/criteria"
+	"errors"
+	"fomrs/internal/api/v1/forms/domain/commands"
+)
+
+// ListAnswersDTO son los query params propios del listado de respuestas;
+// filtros, orden y paginación se leen con AnswersQuerySchema.
+type ListAnswersDTO struct {
+	Cursor     string `form:"cursor"`
+	QuestionID string `form:"question_id"`
+	Value      string `form:"value"`
+}
+
+func (dto ListAnswersDTO) Validate() error {
+
+	if dto.Value != "" && dto.QuestionID == "" {
+		return errors.New("value requires question_id")
+	}
+
+	return nil
+}
+
+func (dto ListAnswersDTO) ToCommand(cri criteria.Criteria) commands.ListAnswersCommand {
+	return commands.ListAnswersCommand{
+		Criteria:   cri,
+		Cursor:     dto.Cursor,
+		QuestionID: dto.QuestionID,
+		Value:      dto.Value,
+	}
+}
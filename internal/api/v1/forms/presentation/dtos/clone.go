@@ -0,0 +1,46 @@
+package dtos
+
+import (
+	"errors"
+	"fomrs/internal/api/v1/forms/domain/commands"
+	"strings"
+)
+
+// CloneFormDTO es el cuerpo (opcional) de POST /v1/forms/:id/clone.
+type CloneFormDTO struct {
+	Title string `json:"title"`
+}
+
+func (dto CloneFormDTO) Validate() error {
+	if dto.Title != "" && strings.TrimSpace(dto.Title) == "" {
+		return errors.New("title must not be blank")
+	}
+	return nil
+}
+
+func (dto CloneFormDTO) ToCommand() commands.CloneFormCommand {
+	return commands.CloneFormCommand{
+		Title: strings.TrimSpace(dto.Title),
+	}
+}
+
+// InstantiateTemplateDTO es el cuerpo de POST /v1/forms/:id/instantiate: el
+// valor de cada parámetro {{nombre}} de la plantilla.
+type InstantiateTemplateDTO struct {
+	Title      string            `json:"title"`
+	Parameters map[string]string `json:"parameters"`
+}
+
+func (dto InstantiateTemplateDTO) Validate() error {
+	if dto.Title != "" && strings.TrimSpace(dto.Title) == "" {
+		return errors.New("title must not be blank")
+	}
+	return nil
+}
+
+func (dto InstantiateTemplateDTO) ToCommand() commands.InstantiateTemplateCommand {
+	return commands.InstantiateTemplateCommand{
+		Title:      strings.TrimSpace(dto.Title),
+		Parameters: dto.Parameters,
+	}
+}
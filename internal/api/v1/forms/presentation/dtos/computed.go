@@ -0,0 +1,122 @@
+package dtos
+
+import (
+	"errors"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/utils"
+	"strings"
+)
+
+// validateComputed comprueba que los campos computed solo usen preguntas del
+// formulario y que no dependan unos de otros en ciclo.
+func validateComputed(questions []QuestionDTO) error {
+
+	ids := make(map[string]bool, len(questions))
+	for _, question := range questions {
+		if question.ID != "" {
+			ids[question.ID] = true
+		}
+	}
+
+	dependencies := map[string][]string{}
+
+	for _, question := range questions {
+
+		if utils.QuestionType(question.Type) != utils.QuestionTypeComputed {
+			continue
+		}
+
+		if question.ID == "" {
+			return errors.New("computed question requires an id: " + question.Title)
+		}
+
+		field, err := utils.ParseComputedField(question.Metadata)
+		if err != nil {
+			return errors.New("invalid metadata for question " + question.Title + ": " + err.Error())
+		}
+
+		for _, id := range field.QuestionIDs() {
+			if !ids[id] {
+				return errors.New("computed question " + question.ID + " references unknown question: " + id)
+			}
+		}
+
+		dependencies[question.ID] = field.QuestionIDs()
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+
+	var visit func(id string, path []string) error
+	visit = func(id string, path []string) error {
+
+		switch state[id] {
+		case visiting:
+			return errors.New("computed questions form a cycle: " + strings.Join(append(path, id), " -> "))
+		case done:
+			return nil
+		}
+
+		state[id] = visiting
+		for _, dependency := range dependencies[id] {
+			if err := visit(dependency, append(path, id)); err != nil {
+				return err
+			}
+		}
+		state[id] = done
+		return nil
+	}
+
+	for _, question := range questions {
+		if _, ok := dependencies[question.ID]; ok {
+			if err := visit(question.ID, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateServerFieldConditions rechaza las condiciones de visibilidad que
+// dependen de campos hidden o computed: el servidor los rellena después de
+// validar la visibilidad y los borradores no los tienen, así que la condición
+// nunca vería su valor.
+func validateServerFieldConditions(questions []QuestionDTO, sections []entities.SectionEntity) error {
+
+	serverSide := make(map[string]bool)
+	for _, question := range questions {
+		if question.ID != "" && utils.IsServerSide(utils.QuestionType(question.Type)) {
+			serverSide[question.ID] = true
+		}
+	}
+
+	check := func(owner string, condition *entities.Condition) error {
+		if condition == nil {
+			return nil
+		}
+		for _, ref := range condition.References() {
+			if serverSide[ref] {
+				return errors.New(owner + ": condition references hidden or computed question " + ref)
+			}
+		}
+		return nil
+	}
+
+	for _, section := range sections {
+		if err := check("section "+section.ID, section.VisibleIf); err != nil {
+			return err
+		}
+	}
+
+	for _, question := range questions {
+		if err := check("question "+question.Title, question.VisibleIf); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
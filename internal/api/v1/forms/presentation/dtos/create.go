@@ -3,58 +3,155 @@ package dtos
 import (
 	"errors"
 	"fomrs/internal/api/v1/forms/domain/commands"
+	"fomrs/internal/api/v1/forms/domain/entities"
 	"fomrs/internal/utils"
-	"slices"
+	"time"
 
 	"common/utils/ctypes"
 )
 
 type QuestionDTO struct {
-	Title       string         `json:"title" binding:"required"`
-	Description string         `json:"description" binding:"required"`
-	Type        string         `json:"type" binding:"required"`
-	Required    bool           `json:"required" binding:"required"`
-	Section     string         `json:"section"`
-	Metadata    map[string]any `json:"metadata"`
+	ID          string              `json:"id"`
+	Title       string              `json:"title" binding:"required"`
+	Description string              `json:"description" binding:"required"`
+	Type        string              `json:"type" binding:"required"`
+	Required    bool                `json:"required" binding:"required"`
+	Section     string              `json:"section"`
+	Order       int                 `json:"order"`
+	Metadata    map[string]any      `json:"metadata"`
+	VisibleIf   *entities.Condition `json:"visible_if"`
+
+	Scoring *entities.QuestionScoring `json:"scoring"`
 }
 
 func (question QuestionDTO) Validate() error {
 
-	if !slices.Contains(utils.QuestionTypes, utils.QuestionType(question.Type)) {
+	if !utils.IsRegisteredType(utils.QuestionType(question.Type)) {
 		return errors.New("invalid question type: " + question.Type)
 	}
 
+	if question.Order < 0 {
+		return errors.New("question order must be greater or equal than 0: " + question.Title)
+	}
+
+	validator, err := utils.NewValidator(utils.QuestionType(question.Type), question.Metadata)
+	if err != nil {
+		return errors.New("invalid metadata for question " + question.Title + ": " + err.Error())
+	}
+
+	if question.Scoring != nil {
+		if err := validateScoring(question, validator); err != nil {
+			return errors.New("invalid scoring for question " + question.Title + ": " + err.Error())
+		}
+	}
+
 	return nil
 }
 
 func (question QuestionDTO) ToCommand() commands.QuestionCommand {
 
 	return commands.QuestionCommand{
+		ID:          question.ID,
 		Title:       question.Title,
 		Description: question.Description,
 		Type:        question.Type,
 		Required:    question.Required,
 		Section:     question.Section,
+		Order:       question.Order,
 		Metadata:    question.Metadata,
+		VisibleIf:   question.VisibleIf,
+		Scoring:     question.Scoring,
 	}
 }
 
-type CreateFormDTO struct {
-	Title       string        `json:"title" binding:"required"`
-	Description string        `json:"description" binding:"required"`
-	Questions   []QuestionDTO `json:"questions" binding:"required"`
-}
+// validateQuestions valida cada pregunta y que los ids enviados no se repitan.
+func validateQuestions(questions []QuestionDTO) error {
 
-func (dto CreateFormDTO) Validate() error {
+	ids := make(map[string]bool, len(questions))
 
-	for _, question := range dto.Questions {
+	for _, question := range questions {
 		if err := question.Validate(); err != nil {
 			return err
 		}
+
+		if question.ID == "" {
+			continue
+		}
+
+		if ids[question.ID] {
+			return errors.New("duplicated question id: " + question.ID)
+		}
+		ids[question.ID] = true
 	}
 	return nil
 }
 
+// validateStructure valida preguntas, secciones y condiciones de visibilidad.
+func validateStructure(questions []QuestionDTO, sections []entities.SectionEntity) error {
+
+	if err := validateQuestions(questions); err != nil {
+		return err
+	}
+
+	if err := validateComputed(questions); err != nil {
+		return err
+	}
+
+	ids := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		if section.ID == "" {
+			return errors.New("section id is required")
+		}
+		if ids[section.ID] {
+			return errors.New("duplicated section id: " + section.ID)
+		}
+		if section.Order < 0 {
+			return errors.New("section order must be greater or equal than 0: " + section.ID)
+		}
+		ids[section.ID] = true
+	}
+
+	if err := entities.ValidateConditions(
+		ctypes.Map(questions, func(question QuestionDTO) entities.QuestionEntity {
+			return question.ToCommand().ToEntity()
+		}),
+		sections,
+	); err != nil {
+		return err
+	}
+
+	return validateServerFieldConditions(questions, sections)
+}
+
+// validateSchedule valida que la ventana de recepción de respuestas sea coherente.
+func validateSchedule(opensAt *time.Time, closesAt *time.Time) error {
+	if opensAt != nil && closesAt != nil && !closesAt.After(*opensAt) {
+		return errors.New("closes_at must be after opens_at")
+	}
+	return nil
+}
+
+type CreateFormDTO struct {
+	Title       string                   `json:"title" binding:"required"`
+	Description string                   `json:"description" binding:"required"`
+	Questions   []QuestionDTO            `json:"questions" binding:"required"`
+	Sections    []entities.SectionEntity `json:"sections"`
+	OpensAt     *time.Time               `json:"opens_at"`
+	ClosesAt    *time.Time               `json:"closes_at"`
+	Settings    entities.FormSettings    `json:"settings"`
+	IsTemplate  bool                     `json:"is_template"`
+}
+
+func (dto CreateFormDTO) Validate() error {
+	if err := validateSchedule(dto.OpensAt, dto.ClosesAt); err != nil {
+		return err
+	}
+	if err := dto.Settings.Validate(); err != nil {
+		return err
+	}
+	return validateStructure(dto.Questions, dto.Sections)
+}
+
 func (dto CreateFormDTO) ToCommand() commands.CreateFormCommand {
 	return commands.CreateFormCommand{
 		Title:       dto.Title,
@@ -65,5 +162,10 @@ func (dto CreateFormDTO) ToCommand() commands.CreateFormCommand {
 				return question.ToCommand()
 			},
 		),
+		Sections:   dto.Sections,
+		OpensAt:    dto.OpensAt,
+		ClosesAt:   dto.ClosesAt,
+		Settings:   dto.Settings,
+		IsTemplate: dto.IsTemplate,
 	}
 }
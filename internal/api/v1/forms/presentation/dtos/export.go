@@ -0,0 +1,57 @@
+package dtos
+
+import (
+	"common/domain/criteria"
+	"errors"
+	"fmt"
+	"fomrs/internal/api/v1/forms/domain/commands"
+	"fomrs/internal/export"
+	"slices"
+)
+
+const (
+	ExportMultiJoin  = "join"
+	ExportMultiSplit = "split"
+)
+
+// ExportAnswersDTO son los query params propios de la exportación de
+// respuestas; los filtros se leen con AnswersQuerySchema, como en el listado.
+type ExportAnswersDTO struct {
+	Format     string `form:"format"`
+	Multi      string `form:"multi"`
+	QuestionID string `form:"question_id"`
+	Value      string `form:"value"`
+}
+
+func (dto ExportAnswersDTO) Validate() error {
+
+	if dto.Format != "" && !slices.Contains(export.Formats, dto.Format) {
+		return fmt.Errorf("format must be one of %v", export.Formats)
+	}
+
+	if dto.Multi != "" && dto.Multi != ExportMultiJoin && dto.Multi != ExportMultiSplit {
+		return fmt.Errorf("multi must be %s or %s", ExportMultiJoin, ExportMultiSplit)
+	}
+
+	if dto.Value != "" && dto.QuestionID == "" {
+		return errors.New("value requires question_id")
+	}
+
+	return nil
+}
+
+func (dto ExportAnswersDTO) ToCommand(cri criteria.Criteria) commands.ExportAnswersCommand {
+
+	format := dto.Format
+	if format == "" {
+		format = export.FormatCSV
+	}
+
+	return commands.ExportAnswersCommand{
+		Criteria:     cri,
+		QuestionID:   dto.QuestionID,
+		Value:        dto.Value,
+		Format:       format,
+		SplitOptions: dto.Multi == ExportMultiSplit,
+	}
+}
@@ -0,0 +1,68 @@
+package dtos
+
+import (
+	"errors"
+	"fomrs/internal/api/v1/forms/domain/commands"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/jsonschema"
+
+	"common/utils/ctypes"
+)
+
+// ImportFormDTO es un JSON Schema (draft 2020-12) de tipo object: el de las
+// respuestas que emite GET /v1/forms/:id/schema o uno con una propiedad por
+// pregunta. El título del esquema es el del formulario.
+type ImportFormDTO struct {
+	jsonschema.Schema
+}
+
+func (dto ImportFormDTO) Validate() error {
+
+	if dto.Title == "" {
+		return errors.New("schema title is required")
+	}
+
+	questions, err := dto.questions()
+	if err != nil {
+		return err
+	}
+
+	return validateStructure(questions, dto.Sections)
+}
+
+func (dto ImportFormDTO) ToCommand() commands.CreateFormCommand {
+
+	// Validate ya comprobó que el esquema se puede convertir.
+	questions, _ := dto.questions()
+
+	return commands.CreateFormCommand{
+		Title:       dto.Title,
+		Description: dto.Description,
+		Questions: ctypes.Map(questions, func(question QuestionDTO) commands.QuestionCommand {
+			return question.ToCommand()
+		}),
+		Sections: dto.Sections,
+	}
+}
+
+func (dto ImportFormDTO) questions() ([]QuestionDTO, error) {
+
+	questions, err := jsonschema.ToQuestions(dto.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctypes.Map(questions, func(question entities.QuestionEntity) QuestionDTO {
+		return QuestionDTO{
+			ID:          question.ID,
+			Title:       question.Title,
+			Description: question.Description,
+			Type:        question.Type,
+			Required:    question.Required,
+			Section:     question.Section,
+			Order:       question.Order,
+			Metadata:    question.Metadata,
+			VisibleIf:   question.VisibleIf,
+		}
+	}), nil
+}
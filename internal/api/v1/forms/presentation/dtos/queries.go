@@ -0,0 +1,57 @@
+package dtos
+
+import (
+	"common/domain/criteria"
+	"common/interface/cdtos"
+)
+
+var dateOperators = []string{"eq", "gt", "gte", "lt", "lte", "between"}
+
+// FormsQuerySchema son los filtros y ordenamientos permitidos en GET /v1/forms.
+var FormsQuerySchema = cdtos.QuerySchema{
+	Fields: map[string]cdtos.QueryField{
+		"title":       {Type: cdtos.FieldTypeString, Operators: []string{"eq", "like"}, Sortable: true},
+		"description": {Type: cdtos.FieldTypeString, Operators: []string{"like"}},
+		"status":      {Type: cdtos.FieldTypeString, Operators: []string{"eq", "ne", "in", "nin"}},
+		"version":     {Type: cdtos.FieldTypeInt, Operators: []string{"eq", "gt", "gte", "lt", "lte"}, Sortable: true},
+		"created_at":  {Type: cdtos.FieldTypeDate, Operators: dateOperators, Sortable: true},
+		"updated_at":  {Type: cdtos.FieldTypeDate, Operators: dateOperators, Sortable: true},
+	},
+	DefaultSort:  []criteria.Order{criteria.NewOrder("created_at", criteria.OrderTypeDesc)},
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// answersFields son los filtros de las respuestas de un formulario.
+var answersFields = map[string]cdtos.QueryField{
+	"user_id":      {Type: cdtos.FieldTypeString, Operators: []string{"eq", "ne", "in", "exists"}},
+	"form_version": {Type: cdtos.FieldTypeInt, Operators: []string{"eq", "gt", "gte", "lt", "lte", "in"}},
+	"created_at":   {Type: cdtos.FieldTypeDate, Operators: dateOperators, Sortable: true},
+}
+
+// answersAliases mantiene los parámetros user_id, from y to del listado
+// anterior a filter[...]; to incluye el día completo.
+var answersAliases = map[string]cdtos.QueryAlias{
+	"user_id": {Field: "user_id", Operator: "eq"},
+	"from":    {Field: "created_at", Operator: "gte"},
+	"to":      {Field: "created_at", Operator: "lte"},
+}
+
+// AnswersQuerySchema son los filtros y ordenamientos permitidos en GET /v1/forms/:id/answers.
+// Solo se ordena por created_at para que el cursor siga siendo válido.
+var AnswersQuerySchema = cdtos.QuerySchema{
+	Fields:       answersFields,
+	DefaultSort:  []criteria.Order{criteria.NewOrder("created_at", criteria.OrderTypeAsc)},
+	DefaultLimit: 10,
+	MaxLimit:     100,
+	Params:       []string{"cursor", "question_id", "value"},
+	Aliases:      answersAliases,
+}
+
+// ExportAnswersQuerySchema son los filtros de GET /v1/forms/:id/answers/export.
+var ExportAnswersQuerySchema = cdtos.QuerySchema{
+	Fields:      answersFields,
+	DefaultSort: []criteria.Order{criteria.NewOrder("created_at", criteria.OrderTypeAsc)},
+	Params:      []string{"format", "multi", "question_id", "value"},
+	Aliases:     answersAliases,
+}
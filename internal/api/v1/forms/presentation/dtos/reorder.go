@@ -0,0 +1,53 @@
+package dtos
+
+import (
+	"errors"
+	"fomrs/internal/api/v1/forms/domain/commands"
+)
+
+type ReorderSectionsDTO struct {
+	SectionIDs []string `json:"section_ids" binding:"required"`
+}
+
+func (dto ReorderSectionsDTO) Validate() error {
+	return validateIDs("section_ids", dto.SectionIDs)
+}
+
+func (dto ReorderSectionsDTO) ToCommand() commands.ReorderSectionsCommand {
+	return commands.ReorderSectionsCommand{
+		SectionIDs: dto.SectionIDs,
+	}
+}
+
+type ReorderQuestionsDTO struct {
+	SectionID   string   `json:"section_id"`
+	QuestionIDs []string `json:"question_ids" binding:"required"`
+}
+
+func (dto ReorderQuestionsDTO) Validate() error {
+	return validateIDs("question_ids", dto.QuestionIDs)
+}
+
+func (dto ReorderQuestionsDTO) ToCommand() commands.ReorderQuestionsCommand {
+	return commands.ReorderQuestionsCommand{
+		SectionID:   dto.SectionID,
+		QuestionIDs: dto.QuestionIDs,
+	}
+}
+
+// validateIDs valida que la lista no esté vacía ni tenga ids repetidos.
+func validateIDs(field string, ids []string) error {
+
+	if len(ids) == 0 {
+		return errors.New(field + " must not be empty")
+	}
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return errors.New("duplicated id in " + field + ": " + id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package dtos
+
+import (
+	"errors"
+	"fomrs/internal/utils"
+	"slices"
+	"strings"
+)
+
+// validateScoring comprueba que las respuestas correctas sean respuestas
+// válidas de la pregunta y que el crédito parcial solo se use donde tiene
+// sentido (varios valores o matrices).
+func validateScoring(question QuestionDTO, validator utils.Validator) error {
+
+	scoring := question.Scoring
+
+	if err := scoring.Validate(); err != nil {
+		return err
+	}
+
+	questionType := utils.QuestionType(question.Type)
+
+	switch {
+	case utils.IsMultiValue(questionType):
+		options, _, err := utils.MetadataStrings(question.Metadata, utils.MetadataOptions)
+		if err != nil {
+			return err
+		}
+		for _, answer := range scoring.CorrectAnswers {
+			if !slices.Contains(options, answer) {
+				return errors.New("correct answer is not an option: " + answer)
+			}
+		}
+
+	case utils.IsMatrix(questionType):
+		rows, _, _ := utils.MetadataStrings(question.Metadata, utils.MetadataRows)
+		columns, _, _ := utils.MetadataStrings(question.Metadata, utils.MetadataColumns)
+		for _, answer := range scoring.CorrectAnswers {
+			row, column, ok := strings.Cut(answer, ":")
+			if !ok || !slices.Contains(rows, row) || !slices.Contains(columns, column) {
+				return errors.New("correct answer must be row:column of the matrix: " + answer)
+			}
+		}
+
+	default:
+		if scoring.PartialCredit {
+			return errors.New("partial_credit is only allowed in multi-value and matrix questions")
+		}
+		if utils.IsServerSide(questionType) {
+			break
+		}
+		for _, answer := range scoring.CorrectAnswers {
+			if !validator.IsValid(answer) {
+				return errors.New("correct answer is not a valid answer: " + answer)
+			}
+		}
+	}
+
+	return nil
+}
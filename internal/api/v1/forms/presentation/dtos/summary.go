@@ -0,0 +1,57 @@
+package dtos
+
+import (
+	"fmt"
+	"fomrs/internal/api/v1/forms/domain/commands"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"slices"
+)
+
+const (
+	DefaultSummaryBuckets = 10
+	MaxSummaryBuckets     = 50
+)
+
+var summaryIntervals = []string{
+	entities.SummaryIntervalDay,
+	entities.SummaryIntervalWeek,
+	entities.SummaryIntervalMonth,
+	entities.SummaryIntervalYear,
+}
+
+// SummaryDTO son los query params de GET /v1/forms/:id/summary.
+type SummaryDTO struct {
+	Interval string `form:"interval"`
+	Buckets  int    `form:"buckets"`
+}
+
+func (dto SummaryDTO) Validate() error {
+
+	if dto.Interval != "" && !slices.Contains(summaryIntervals, dto.Interval) {
+		return fmt.Errorf("interval must be one of %v", summaryIntervals)
+	}
+
+	if dto.Buckets < 0 || dto.Buckets > MaxSummaryBuckets {
+		return fmt.Errorf("buckets must be between 1 and %d", MaxSummaryBuckets)
+	}
+
+	return nil
+}
+
+func (dto SummaryDTO) ToCommand() commands.SummaryCommand {
+
+	command := commands.SummaryCommand{
+		Interval: dto.Interval,
+		Buckets:  dto.Buckets,
+	}
+
+	if command.Interval == "" {
+		command.Interval = entities.SummaryIntervalDay
+	}
+
+	if command.Buckets == 0 {
+		command.Buckets = DefaultSummaryBuckets
+	}
+
+	return command
+}
@@ -0,0 +1,50 @@
+package dtos
+
+import (
+	"common/utils/ctypes"
+	"fomrs/internal/api/v1/forms/domain/commands"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"time"
+)
+
+// UpdateFormDTO reemplaza el contenido del formulario. Las preguntas que
+// conservan su id mantienen la relación con las respuestas existentes.
+type UpdateFormDTO struct {
+	Title       string                   `json:"title" binding:"required"`
+	Description string                   `json:"description" binding:"required"`
+	Questions   []QuestionDTO            `json:"questions" binding:"required"`
+	Sections    []entities.SectionEntity `json:"sections"`
+	OpensAt     *time.Time               `json:"opens_at"`
+	ClosesAt    *time.Time               `json:"closes_at"`
+	Settings    entities.FormSettings    `json:"settings"`
+	// IsTemplate solo cambia si se envía.
+	IsTemplate *bool `json:"is_template"`
+}
+
+func (dto UpdateFormDTO) Validate() error {
+	if err := validateSchedule(dto.OpensAt, dto.ClosesAt); err != nil {
+		return err
+	}
+	if err := dto.Settings.Validate(); err != nil {
+		return err
+	}
+	return validateStructure(dto.Questions, dto.Sections)
+}
+
+func (dto UpdateFormDTO) ToCommand() commands.UpdateFormCommand {
+	return commands.UpdateFormCommand{
+		Title:       dto.Title,
+		Description: dto.Description,
+		Questions: ctypes.Map(
+			dto.Questions,
+			func(question QuestionDTO) commands.QuestionCommand {
+				return question.ToCommand()
+			},
+		),
+		Sections:   dto.Sections,
+		OpensAt:    dto.OpensAt,
+		ClosesAt:   dto.ClosesAt,
+		Settings:   dto.Settings,
+		IsTemplate: dto.IsTemplate,
+	}
+}
@@ -1,11 +1,14 @@
 package forms
 
 import (
+	"context"
 	"fomrs/internal/api/v1/forms/app/services"
 	"fomrs/internal/api/v1/forms/presentation/controllers"
 	"fomrs/internal/core/settings"
 	"fomrs/internal/db/mongo/answers"
 	"fomrs/internal/db/mongo/forms"
+	"fomrs/internal/db/mongo/revisions"
+	"log"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,8 +28,18 @@ func SetupFormsModule(router *gin.Engine) {
 		"answers",
 	)
 
+	revisionsRepository := revisions.NewRevisionsMongoRepository(
+		settings.Settings.MONGO_DSN,
+		"forms_db",
+		"form_revisions",
+	)
+
+	if err := revisionsRepository.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Error creating form_revisions indexes: %v", err)
+	}
+
 	// Services
-	formsService := services.NewFormsService(formsRepository, answersRepository)
+	formsService := services.NewFormsService(formsRepository, answersRepository, revisionsRepository)
 
 	// Controllers
 	formsController := controllers.NewFormsController(formsService)
@@ -35,6 +48,19 @@ func SetupFormsModule(router *gin.Engine) {
 	formsGroup := router.Group("/v1/forms")
 	formsGroup.POST("", formsController.Create)
 	formsGroup.GET("", formsController.List)
+	formsGroup.GET("/templates", formsController.Templates)
+	formsGroup.POST("/import", formsController.Import)
 	formsGroup.GET("/:id", formsController.Retrieve)
+	formsGroup.GET("/:id/schema", formsController.Schema)
+	formsGroup.PUT("/:id", formsController.Update)
+	formsGroup.PUT("/:id/sections/order", formsController.ReorderSections)
+	formsGroup.PUT("/:id/questions/order", formsController.ReorderQuestions)
+	formsGroup.POST("/:id/publish", formsController.Publish)
+	formsGroup.POST("/:id/close", formsController.Close)
+	formsGroup.POST("/:id/archive", formsController.Archive)
+	formsGroup.POST("/:id/clone", formsController.Clone)
+	formsGroup.POST("/:id/instantiate", formsController.Instantiate)
 	formsGroup.GET("/:id/answers", formsController.Answers)
+	formsGroup.GET("/:id/answers/export", formsController.ExportAnswers)
+	formsGroup.GET("/:id/summary", formsController.Summary)
 }
@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -26,6 +27,25 @@ type Config struct {
 	MONGO_DSN string `required:"true"`
 
 	LOKI_URL string `required:"false" default:"http://localhost:3100"`
+
+	// ADMIN_TOKEN habilita las lecturas de administración (p. ej. las
+	// respuestas correctas de los quizzes); vacío las deshabilita.
+	ADMIN_TOKEN string `required:"false"`
+
+	// Answers
+	DRAFT_TTL time.Duration `required:"false" default:"168h"`
+
+	// Storage
+	STORAGE_DRIVER    string `required:"false" default:"local"`
+	STORAGE_LOCAL_DIR string `required:"false" default:"uploads"`
+	S3_ENDPOINT       string `required:"false"`
+	S3_REGION         string `required:"false" default:"us-east-1"`
+	S3_BUCKET         string `required:"false"`
+	S3_ACCESS_KEY     string `required:"false"`
+	S3_SECRET_KEY     string `required:"false"`
+	UPLOAD_MAX_SIZE   int64  `required:"false" default:"10485760"`
+	// UPLOAD_MAX_BODY_SIZE es el tope global del cuerpo de una subida.
+	UPLOAD_MAX_BODY_SIZE int64 `required:"false" default:"52428800"`
 }
 
 var Settings Config
@@ -1,21 +1,59 @@
 package answers
 
-import "fomrs/internal/api/v1/answers/domain/entities"
+import (
+	"fomrs/internal/api/v1/answers/domain/entities"
+	"time"
+)
+
+// Campos de cada respuesta dentro del array answers. AnswerEntity no declara
+// tags bson, así que el driver los guarda en minúsculas: question_id se guarda
+// como questionid. Los filtros y agregaciones deben usar estos nombres.
+const (
+	FieldAnswers    = "answers"
+	FieldQuestionID = "questionid"
+	FieldAnswer     = "answer"
+	FieldValues     = "values"
+)
 
 // Geolocalization es una implementación de Entity.
 type AnswerModel struct {
-	ID      string                  `json:"id" bson:"_id,omitempty"`
-	FormID  string                  `json:"form_id" bson:"form_id"`
-	Answers []entities.AnswerEntity `json:"answers" bson:"answers"`
+	ID          string                  `json:"id" bson:"_id,omitempty"`
+	FormID      string                  `json:"form_id" bson:"form_id"`
+	FormVersion int                     `json:"form_version" bson:"form_version"`
+	UserID      string                  `json:"user_id" bson:"user_id"`
+	ClientIP    string                  `json:"client_ip" bson:"client_ip"`
+	UserAgent   string                  `json:"user_agent" bson:"user_agent"`
+	Answers     []entities.AnswerEntity `json:"answers" bson:"answers"`
+	CreatedAt   time.Time               `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at" bson:"updated_at"`
+	DeletedAt   *time.Time              `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+	DeletedBy   string                  `json:"deleted_by,omitempty" bson:"deleted_by,omitempty"`
+
+	// Score es la nota en formularios con quiz.
+	Score *entities.ScoreEntity `json:"score,omitempty" bson:"score,omitempty"`
+
+	// SingleSubmission marca las respuestas de formularios con una sola
+	// respuesta por usuario; el índice único (form_id, user_id) solo se aplica
+	// a ellas.
+	SingleSubmission bool `json:"-" bson:"single_submission,omitempty"`
 }
 
 func (g AnswerModel) GetID() string {
 	return g.ID
 }
 
+// IsDeleted indica si la respuesta fue eliminada (borrado lógico).
+func (g AnswerModel) IsDeleted() bool {
+	return g.DeletedAt != nil
+}
+
 type AnswerListModel struct {
-	ID     string `json:"id" bson:"_id,omitempty"`
-	FormID string `json:"form_id" bson:"form_id"`
+	ID          string    `json:"id" bson:"_id,omitempty"`
+	FormID      string    `json:"form_id" bson:"form_id"`
+	FormVersion int       `json:"form_version" bson:"form_version"`
+	UserID      string    `json:"user_id" bson:"user_id"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
 }
 
 func (g AnswerListModel) GetID() string {
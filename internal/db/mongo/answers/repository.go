@@ -2,6 +2,11 @@ package answers
 
 import (
 	ppmongo "common/infrastructure/db/ppmongo"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // --------------------------------------
@@ -16,3 +21,15 @@ func NewAnswersMongoRepository(uri string, dbName string, collectionName string)
 		MongoRepository: ppmongo.NewMongoRepository[AnswerModel, AnswerListModel](uri, dbName, collectionName),
 	}
 }
+
+// EnsureIndexes crea el índice único (form_id, user_id) que garantiza una sola
+// respuesta por usuario en los formularios que lo exigen.
+func (r *AnswersMongoRepository) EnsureIndexes(ctx context.Context) error {
+	return r.CreateIndexes(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "form_id", Value: 1}, {Key: "user_id", Value: 1}},
+		Options: options.Index().
+			SetName("form_id_user_id_single_submission").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"single_submission": true}),
+	})
+}
@@ -0,0 +1,319 @@
+package answers
+
+import (
+	"common/utils"
+	"common/utils/cerrs"
+	"context"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// questionIDPath es el id de la pregunta de cada elemento de answers.
+const questionIDPath = FieldAnswers + "." + FieldQuestionID
+
+// PeriodFormats son los formatos de $dateToString con los que se agrupan las
+// respuestas y las fechas por periodo.
+var PeriodFormats = map[string]string{
+	"day":   "%Y-%m-%d",
+	"week":  "%G-W%V",
+	"month": "%Y-%m",
+	"year":  "%Y",
+}
+
+// ValueCount es el número de veces que se eligió un valor en una pregunta.
+type ValueCount struct {
+	QuestionID string `bson:"question_id"`
+	Value      string `bson:"value"`
+	Count      int64  `bson:"count"`
+}
+
+// QuestionCount es el número de respuestas que contestan una pregunta.
+type QuestionCount struct {
+	QuestionID string `bson:"question_id"`
+	Count      int64  `bson:"count"`
+}
+
+// MatrixCount es el número de veces que se marcó una columna en una fila.
+type MatrixCount struct {
+	QuestionID string `bson:"question_id"`
+	Row        string `bson:"row"`
+	Column     string `bson:"column"`
+	Count      int64  `bson:"count"`
+}
+
+// NumberStats resume los valores numéricos de una pregunta.
+type NumberStats struct {
+	QuestionID string  `bson:"question_id"`
+	Count      int64   `bson:"count"`
+	Min        float64 `bson:"min"`
+	Max        float64 `bson:"max"`
+	Avg        float64 `bson:"avg"`
+}
+
+// BucketCount es un tramo del histograma. Overflow agrupa los valores iguales
+// o mayores que el último límite.
+type BucketCount struct {
+	Lower    float64
+	Overflow bool
+	Count    int64
+}
+
+// PeriodCount es el número de elementos de un periodo; QuestionID queda vacío
+// en la evolución de las respuestas.
+type PeriodCount struct {
+	QuestionID string `bson:"question_id"`
+	Period     string `bson:"period"`
+	Count      int64  `bson:"count"`
+}
+
+// CountValues cuenta cuántas respuestas eligieron cada valor de las preguntas
+// indicadas; en las de varios valores cuenta cada valor marcado.
+func (r *AnswersMongoRepository) CountValues(ctx context.Context, formID string, questionIDs []string) utils.Result[[]ValueCount] {
+
+	pipeline := append(questionsPipeline(formID, questionIDs),
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"question_id": "$" + questionIDPath,
+			"value": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{bson.M{"$size": bson.M{"$ifNull": bson.A{"$answers.values", bson.A{}}}}, 0}},
+				"$answers.values",
+				bson.A{"$answers.answer"},
+			}},
+		}}},
+		bson.D{{Key: "$unwind", Value: "$value"}},
+		bson.D{{Key: "$match", Value: bson.M{"value": bson.M{"$nin": bson.A{"", nil}}}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"question_id": "$question_id", "value": "$value"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"question_id": "$_id.question_id",
+			"value":       "$_id.value",
+			"count":       1,
+		}}},
+	)
+
+	return aggregate[ValueCount](ctx, r.Collection, pipeline, "mongo.aggregate.count_values")
+}
+
+// CountAnswered cuenta las respuestas que contestan cada pregunta.
+func (r *AnswersMongoRepository) CountAnswered(ctx context.Context, formID string, questionIDs []string) utils.Result[[]QuestionCount] {
+
+	pipeline := append(questionsPipeline(formID, questionIDs),
+		bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{"answers.answer": bson.M{"$nin": bson.A{"", nil}}},
+			bson.M{"answers.values.0": bson.M{"$exists": true}},
+			bson.M{"answers.matrix.0": bson.M{"$exists": true}},
+			bson.M{"answers.file": bson.M{"$type": "object"}},
+		}}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":   "$" + questionIDPath,
+			"count": bson.M{"$sum": 1},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"question_id": "$_id",
+			"count":       1,
+		}}},
+	)
+
+	return aggregate[QuestionCount](ctx, r.Collection, pipeline, "mongo.aggregate.count_answered")
+}
+
+// CountMatrix cuenta las columnas marcadas en cada fila de las matrices.
+func (r *AnswersMongoRepository) CountMatrix(ctx context.Context, formID string, questionIDs []string) utils.Result[[]MatrixCount] {
+
+	pipeline := append(questionsPipeline(formID, questionIDs),
+		bson.D{{Key: "$unwind", Value: "$answers.matrix"}},
+		bson.D{{Key: "$unwind", Value: "$answers.matrix.columns"}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"question_id": "$" + questionIDPath,
+				"row":         "$answers.matrix.row",
+				"column":      "$answers.matrix.columns",
+			},
+			"count": bson.M{"$sum": 1},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"question_id": "$_id.question_id",
+			"row":         "$_id.row",
+			"column":      "$_id.column",
+			"count":       1,
+		}}},
+	)
+
+	return aggregate[MatrixCount](ctx, r.Collection, pipeline, "mongo.aggregate.count_matrix")
+}
+
+// NumberStats calcula mínimo, máximo y media de las respuestas numéricas.
+func (r *AnswersMongoRepository) NumberStats(ctx context.Context, formID string, questionIDs []string) utils.Result[[]NumberStats] {
+
+	pipeline := append(numbersPipeline(formID, questionIDs),
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":   "$question_id",
+			"count": bson.M{"$sum": 1},
+			"min":   bson.M{"$min": "$number"},
+			"max":   bson.M{"$max": "$number"},
+			"avg":   bson.M{"$avg": "$number"},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"question_id": "$_id",
+			"count":       1,
+			"min":         1,
+			"max":         1,
+			"avg":         1,
+		}}},
+	)
+
+	return aggregate[NumberStats](ctx, r.Collection, pipeline, "mongo.aggregate.number_stats")
+}
+
+// NumberHistogram reparte las respuestas numéricas de una pregunta en los
+// tramos [boundaries[i], boundaries[i+1]).
+func (r *AnswersMongoRepository) NumberHistogram(ctx context.Context, formID string, questionID string, boundaries []float64) utils.Result[[]BucketCount] {
+
+	pipeline := append(numbersPipeline(formID, []string{questionID}),
+		bson.D{{Key: "$bucket", Value: bson.M{
+			"groupBy":    "$number",
+			"boundaries": boundaries,
+			"default":    "overflow",
+			"output":     bson.M{"count": bson.M{"$sum": 1}},
+		}}},
+	)
+
+	type bucket struct {
+		ID    bson.RawValue `bson:"_id"`
+		Count int64         `bson:"count"`
+	}
+
+	res := aggregate[bucket](ctx, r.Collection, pipeline, "mongo.aggregate.number_histogram")
+	if res.Err != nil {
+		return utils.Result[[]BucketCount]{Err: res.Err}
+	}
+
+	buckets := make([]BucketCount, 0, len(res.Data))
+	for _, b := range res.Data {
+		if b.ID.Type == bsontype.String {
+			buckets = append(buckets, BucketCount{Overflow: true, Count: b.Count})
+			continue
+		}
+		lower, ok := b.ID.DoubleOK()
+		if !ok {
+			continue
+		}
+		buckets = append(buckets, BucketCount{Lower: lower, Count: b.Count})
+	}
+
+	return utils.Result[[]BucketCount]{Data: buckets}
+}
+
+// DateHistogram agrupa por periodo las respuestas de fecha de las preguntas
+// indicadas. format es uno de PeriodFormats.
+func (r *AnswersMongoRepository) DateHistogram(ctx context.Context, formID string, questionIDs []string, format string) utils.Result[[]PeriodCount] {
+
+	pipeline := append(questionsPipeline(formID, questionIDs),
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"question_id": "$" + questionIDPath,
+			"period": bson.M{"$dateToString": bson.M{
+				"format": format,
+				"date": bson.M{"$dateFromString": bson.M{
+					"dateString": "$answers.answer",
+					"onError":    nil,
+					"onNull":     nil,
+				}},
+			}},
+		}}},
+		bson.D{{Key: "$match", Value: bson.M{"period": bson.M{"$ne": nil}}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"question_id": "$question_id", "period": "$period"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"question_id": "$_id.question_id",
+			"period":      "$_id.period",
+			"count":       1,
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "question_id", Value: 1}, {Key: "period", Value: 1}}}},
+	)
+
+	return aggregate[PeriodCount](ctx, r.Collection, pipeline, "mongo.aggregate.date_histogram")
+}
+
+// Timeline cuenta las respuestas recibidas en cada periodo según created_at.
+func (r *AnswersMongoRepository) Timeline(ctx context.Context, formID string, format string) utils.Result[[]PeriodCount] {
+
+	pipeline := mongo.Pipeline{
+		summaryMatch(formID),
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"$dateToString": bson.M{"format": format, "date": "$created_at"}},
+			"count": bson.M{"$sum": 1},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":    0,
+			"period": "$_id",
+			"count":  1,
+		}}},
+		bson.D{{Key: "$sort", Value: bson.M{"period": 1}}},
+	}
+
+	return aggregate[PeriodCount](ctx, r.Collection, pipeline, "mongo.aggregate.timeline")
+}
+
+// summaryMatch selecciona las respuestas no eliminadas del formulario.
+func summaryMatch(formID string) bson.D {
+	return bson.D{{Key: "$match", Value: bson.M{
+		"form_id":    formID,
+		"deleted_at": bson.M{"$exists": false},
+	}}}
+}
+
+// questionsPipeline deja un documento por cada respuesta a las preguntas indicadas.
+func questionsPipeline(formID string, questionIDs []string) mongo.Pipeline {
+	return mongo.Pipeline{
+		summaryMatch(formID),
+		bson.D{{Key: "$unwind", Value: "$answers"}},
+		bson.D{{Key: "$match", Value: bson.M{questionIDPath: bson.M{"$in": questionIDs}}}},
+	}
+}
+
+// numbersPipeline convierte a número las respuestas y descarta las que no lo son.
+func numbersPipeline(formID string, questionIDs []string) mongo.Pipeline {
+	return append(questionsPipeline(formID, questionIDs),
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"question_id": "$" + questionIDPath,
+			"number": bson.M{"$convert": bson.M{
+				"input":   "$answers.answer",
+				"to":      "double",
+				"onError": nil,
+				"onNull":  nil,
+			}},
+		}}},
+		bson.D{{Key: "$match", Value: bson.M{"number": bson.M{"$ne": nil}}}},
+	)
+}
+
+// aggregate ejecuta el pipeline y decodifica todos los resultados.
+func aggregate[R any](ctx context.Context, collection *mongo.Collection, pipeline mongo.Pipeline, op string) utils.Result[[]R] {
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return utils.Result[[]R]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), op)}
+	}
+	defer cursor.Close(ctx)
+
+	results := []R{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return utils.Result[[]R]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), op)}
+	}
+
+	return utils.Result[[]R]{Data: results}
+}
@@ -0,0 +1,12 @@
+package counters
+
+// FormCounterModel lleva la cuenta de las respuestas activas de un formulario.
+// El _id es el id del formulario.
+type FormCounterModel struct {
+	ID    string `json:"id" bson:"_id"`
+	Total int64  `json:"total" bson:"total"`
+}
+
+func (g FormCounterModel) GetID() string {
+	return g.ID
+}
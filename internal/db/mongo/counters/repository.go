@@ -0,0 +1,88 @@
+package counters
+
+import (
+	ppmongo "common/infrastructure/db/ppmongo"
+	"common/utils"
+	"common/utils/cerrs"
+	"context"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// --------------------------------------
+// Ropository of specific Entity
+// --------------------------------------
+type FormCountersMongoRepository struct {
+	*ppmongo.MongoRepository[FormCounterModel, FormCounterModel]
+}
+
+func NewFormCountersMongoRepository(uri string, dbName string, collectionName string) *FormCountersMongoRepository {
+	return &FormCountersMongoRepository{
+		MongoRepository: ppmongo.NewMongoRepository[FormCounterModel, FormCounterModel](uri, dbName, collectionName),
+	}
+}
+
+// Reserve suma una respuesta al contador del formulario si no ha alcanzado
+// limit (0 = sin límite), en una sola operación atómica: de dos envíos
+// simultáneos con un hueco libre solo uno lo obtiene. Data es false si el
+// límite está alcanzado; si el contador no existe devuelve un error 404 con
+// scope counters.reserve.not_found.
+func (r *FormCountersMongoRepository) Reserve(ctx context.Context, formID string, limit int) utils.Result[bool] {
+
+	filter := bson.M{"_id": formID}
+	if limit > 0 {
+		filter["total"] = bson.M{"$lt": limit}
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"total": 1}})
+	if err != nil {
+		return utils.Result[bool]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "counters.reserve")}
+	}
+
+	if result.MatchedCount > 0 {
+		return utils.Result[bool]{Data: true}
+	}
+
+	exists, err := r.Collection.CountDocuments(ctx, bson.M{"_id": formID})
+	if err != nil {
+		return utils.Result[bool]{Err: cerrs.NewCustomError(http.StatusInternalServerError, err.Error(), "counters.reserve")}
+	}
+
+	if exists == 0 {
+		return utils.Result[bool]{Err: cerrs.NewCustomError(http.StatusNotFound, "el contador del formulario no existe: "+formID, "counters.reserve.not_found")}
+	}
+
+	return utils.Result[bool]{Data: false}
+}
+
+// Init crea el contador del formulario con total respuestas si aún no existe;
+// si otro envío lo creó antes se conserva el suyo.
+func (r *FormCountersMongoRepository) Init(ctx context.Context, formID string, total int64) error {
+
+	_, err := r.Collection.UpdateOne(ctx,
+		bson.M{"_id": formID},
+		bson.M{"$setOnInsert": bson.M{"total": total}},
+		options.Update().SetUpsert(true),
+	)
+
+	// Dos upserts simultáneos: el que pierde choca con el _id ya insertado.
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Release resta una respuesta al contador del formulario, sin bajar de 0.
+func (r *FormCountersMongoRepository) Release(ctx context.Context, formID string) error {
+
+	_, err := r.Collection.UpdateOne(ctx,
+		bson.M{"_id": formID, "total": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"total": -1}},
+	)
+
+	return err
+}
@@ -0,0 +1,49 @@
+package drafts
+
+import (
+	"fomrs/internal/api/v1/answers/domain/entities"
+	"time"
+)
+
+// SubmitTimeout es cuánto dura la reserva de un envío. Pasado ese tiempo se
+// entiende que el envío se interrumpió (p. ej. el proceso cayó) y el borrador
+// puede volver a enviarse sin esperar a que caduque.
+const SubmitTimeout = 2 * time.Minute
+
+// DraftModel es una respuesta en curso que se completa por páginas y se envía
+// al final. Caduca en ExpiresAt si no se vuelve a modificar.
+type DraftModel struct {
+	ID                string                  `json:"id" bson:"_id,omitempty"`
+	FormID            string                  `json:"form_id" bson:"form_id"`
+	FormVersion       int                     `json:"form_version" bson:"form_version"`
+	UserID            string                  `json:"user_id" bson:"user_id"`
+	Answers           []entities.AnswerEntity `json:"answers" bson:"answers"`
+	SubmittedAnswerID string                  `json:"submitted_answer_id,omitempty" bson:"submitted_answer_id,omitempty"`
+	// SubmittingAt marca el borrador mientras se envía, para que dos envíos
+	// simultáneos no creen dos respuestas.
+	SubmittingAt *time.Time `json:"-" bson:"submitting_at,omitempty"`
+	ExpiresAt    time.Time  `json:"expires_at" bson:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" bson:"updated_at"`
+}
+
+func (g DraftModel) GetID() string {
+	return g.ID
+}
+
+// IsSubmitted indica si el borrador ya se convirtió en una respuesta final.
+func (g DraftModel) IsSubmitted() bool {
+	return g.SubmittedAnswerID != ""
+}
+
+// IsSubmitting indica si hay un envío del borrador en curso; una reserva de
+// más de SubmitTimeout no cuenta.
+func (g DraftModel) IsSubmitting(now time.Time) bool {
+	return g.SubmittingAt != nil && now.Sub(*g.SubmittingAt) < SubmitTimeout
+}
+
+// IsExpired indica si el borrador caducó; el índice TTL lo borra con cierto
+// retraso, así que también se comprueba al leerlo.
+func (g DraftModel) IsExpired(now time.Time) bool {
+	return !now.Before(g.ExpiresAt)
+}
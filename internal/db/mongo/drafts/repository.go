@@ -0,0 +1,31 @@
+package drafts
+
+import (
+	ppmongo "common/infrastructure/db/ppmongo"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// --------------------------------------
+// Ropository of specific Entity
+// --------------------------------------
+type DraftsMongoRepository struct {
+	*ppmongo.MongoRepository[DraftModel, DraftModel]
+}
+
+func NewDraftsMongoRepository(uri string, dbName string, collectionName string) *DraftsMongoRepository {
+	return &DraftsMongoRepository{
+		MongoRepository: ppmongo.NewMongoRepository[DraftModel, DraftModel](uri, dbName, collectionName),
+	}
+}
+
+// EnsureIndexes crea el índice TTL que borra los borradores caducados.
+func (r *DraftsMongoRepository) EnsureIndexes(ctx context.Context) error {
+	return r.CreateIndexes(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+}
@@ -1,6 +1,10 @@
 package forms
 
-import "fomrs/internal/api/v1/forms/domain/entities"
+import (
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"slices"
+	"time"
+)
 
 // Geolocalization es una implementación de Entity.
 type FormModel struct {
@@ -8,16 +12,113 @@ type FormModel struct {
 	Title       string                    `json:"title" bson:"title"`
 	Description string                    `json:"description" bson:"description"`
 	Questions   []entities.QuestionEntity `json:"questions" bson:"questions"`
+	Sections    []entities.SectionEntity  `json:"sections,omitempty" bson:"sections,omitempty"`
+	Version     int                       `json:"version" bson:"version"`
+	Status      entities.FormStatus       `json:"status" bson:"status"`
+	OpensAt     *time.Time                `json:"opens_at,omitempty" bson:"opens_at,omitempty"`
+	ClosesAt    *time.Time                `json:"closes_at,omitempty" bson:"closes_at,omitempty"`
+	Settings    entities.FormSettings     `json:"settings" bson:"settings"`
+	CreatedAt   time.Time                 `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at" bson:"updated_at"`
+
+	// IsTemplate marca el formulario como plantilla: se lista aparte y se
+	// instancia con POST /v1/forms/:id/instantiate.
+	IsTemplate bool `json:"is_template" bson:"is_template,omitempty"`
+	// Source es el formulario o plantilla del que se copió.
+	Source *entities.FormSource `json:"source,omitempty" bson:"source,omitempty"`
 }
 
 func (g FormModel) GetID() string {
 	return g.ID
 }
 
+// CurrentVersion devuelve la revisión vigente; los formularios creados antes
+// del versionado no tienen el campo y se consideran la revisión 1.
+func (g FormModel) CurrentVersion() int {
+	if g.Version < 1 {
+		return 1
+	}
+	return g.Version
+}
+
+// CurrentStatus devuelve el estado del formulario; los formularios creados
+// antes del ciclo de vida aceptaban respuestas y se consideran publicados.
+func (g FormModel) CurrentStatus() entities.FormStatus {
+	if g.Status == "" {
+		return entities.FormStatusPublished
+	}
+	return g.Status
+}
+
+// FormDetailModel es la vista de detalle del formulario: además de la lista
+// plana de preguntas, devuelve las secciones ordenadas con sus preguntas.
+type FormDetailModel struct {
+	FormModel
+	Sections []entities.SectionLayout `json:"sections"`
+}
+
+// WithoutScoring oculta las respuestas correctas de las preguntas.
+func (g FormModel) WithoutScoring() FormModel {
+	g.Questions = entities.WithoutScoring(g.Questions)
+	return g
+}
+
+// WithoutScoring oculta las respuestas correctas también en las secciones.
+func (g FormDetailModel) WithoutScoring() FormDetailModel {
+	return g.FormModel.WithoutScoring().Detail()
+}
+
+// TemplateParameters devuelve los parámetros {{nombre}} de los títulos y
+// descripciones del formulario, sus secciones y sus preguntas.
+func (g FormModel) TemplateParameters() []string {
+
+	texts := []string{g.Title, g.Description}
+	for _, section := range g.Sections {
+		texts = append(texts, section.Title, section.Description)
+	}
+	for _, question := range g.Questions {
+		texts = append(texts, question.Title, question.Description)
+	}
+
+	return entities.TemplateParameters(texts...)
+}
+
+// WithParameters sustituye los parámetros de los títulos y descripciones.
+func (g FormModel) WithParameters(values map[string]string) FormModel {
+
+	g.Title = entities.ReplaceParameters(g.Title, values)
+	g.Description = entities.ReplaceParameters(g.Description, values)
+
+	g.Sections = slices.Clone(g.Sections)
+	for i := range g.Sections {
+		g.Sections[i].Title = entities.ReplaceParameters(g.Sections[i].Title, values)
+		g.Sections[i].Description = entities.ReplaceParameters(g.Sections[i].Description, values)
+	}
+
+	g.Questions = slices.Clone(g.Questions)
+	for i := range g.Questions {
+		g.Questions[i].Title = entities.ReplaceParameters(g.Questions[i].Title, values)
+		g.Questions[i].Description = entities.ReplaceParameters(g.Questions[i].Description, values)
+	}
+
+	return g
+}
+
+// Detail construye la vista de detalle del formulario.
+func (g FormModel) Detail() FormDetailModel {
+	return FormDetailModel{
+		FormModel: g,
+		Sections:  entities.Layout(g.Questions, g.Sections),
+	}
+}
+
 type FormListModel struct {
-	ID          string `json:"id" bson:"_id,omitempty"`
-	Title       string `json:"title" bson:"title"`
-	Description string `json:"description" bson:"description"`
+	ID          string              `json:"id" bson:"_id,omitempty"`
+	Title       string              `json:"title" bson:"title"`
+	Description string              `json:"description" bson:"description"`
+	Version     int                 `json:"version" bson:"version"`
+	Status      entities.FormStatus `json:"status" bson:"status"`
+	IsTemplate  bool                `json:"is_template" bson:"is_template,omitempty"`
 }
 
 func (g FormListModel) GetID() string {
@@ -0,0 +1,31 @@
+package history
+
+import (
+	"fomrs/internal/api/v1/answers/domain/entities"
+	"time"
+)
+
+type AnswerAction string
+
+const (
+	AnswerActionUpdate AnswerAction = "update"
+	AnswerActionDelete AnswerAction = "delete"
+)
+
+// AnswerHistoryModel registra un cambio sobre una respuesta enviada. La
+// colección es de solo inserción: los registros nunca se modifican.
+type AnswerHistoryModel struct {
+	ID        string                  `json:"id" bson:"_id,omitempty"`
+	AnswerID  string                  `json:"answer_id" bson:"answer_id"`
+	FormID    string                  `json:"form_id" bson:"form_id"`
+	Action    AnswerAction            `json:"action" bson:"action"`
+	Actor     string                  `json:"actor" bson:"actor"`
+	ClientIP  string                  `json:"client_ip" bson:"client_ip"`
+	Before    []entities.AnswerEntity `json:"before" bson:"before"`
+	After     []entities.AnswerEntity `json:"after" bson:"after"`
+	CreatedAt time.Time               `json:"created_at" bson:"created_at"`
+}
+
+func (g AnswerHistoryModel) GetID() string {
+	return g.ID
+}
@@ -0,0 +1,18 @@
+package history
+
+import (
+	ppmongo "common/infrastructure/db/ppmongo"
+)
+
+// --------------------------------------
+// Ropository of specific Entity
+// --------------------------------------
+type AnswerHistoryMongoRepository struct {
+	*ppmongo.MongoRepository[AnswerHistoryModel, AnswerHistoryModel]
+}
+
+func NewAnswerHistoryMongoRepository(uri string, dbName string, collectionName string) *AnswerHistoryMongoRepository {
+	return &AnswerHistoryMongoRepository{
+		MongoRepository: ppmongo.NewMongoRepository[AnswerHistoryModel, AnswerHistoryModel](uri, dbName, collectionName),
+	}
+}
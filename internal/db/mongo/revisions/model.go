@@ -0,0 +1,19 @@
+package revisions
+
+import (
+	"fomrs/internal/db/mongo/forms"
+	"time"
+)
+
+// FormRevisionModel es una copia inmutable de un formulario en una versión concreta.
+type FormRevisionModel struct {
+	ID        string          `json:"id" bson:"_id,omitempty"`
+	FormID    string          `json:"form_id" bson:"form_id"`
+	Version   int             `json:"version" bson:"version"`
+	Form      forms.FormModel `json:"form" bson:"form"`
+	CreatedAt time.Time       `json:"created_at" bson:"created_at"`
+}
+
+func (g FormRevisionModel) GetID() string {
+	return g.ID
+}
@@ -0,0 +1,40 @@
+package revisions
+
+import (
+	ppmongo "common/infrastructure/db/ppmongo"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// --------------------------------------
+// Ropository of specific Entity
+// --------------------------------------
+type RevisionsMongoRepository struct {
+	*ppmongo.MongoRepository[FormRevisionModel, FormRevisionModel]
+}
+
+func NewRevisionsMongoRepository(uri string, dbName string, collectionName string) *RevisionsMongoRepository {
+	return &RevisionsMongoRepository{
+		MongoRepository: ppmongo.NewMongoRepository[FormRevisionModel, FormRevisionModel](uri, dbName, collectionName),
+	}
+}
+
+// EnsureIndexes crea el índice único (form_id, version): dos ediciones
+// concurrentes no pueden publicar la misma versión.
+func (r *RevisionsMongoRepository) EnsureIndexes(ctx context.Context) error {
+	return r.CreateIndexes(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "form_id", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().
+			SetName("form_id_version").
+			SetUnique(true),
+	})
+}
+
+// DeleteVersion elimina la revisión indicada de un formulario.
+func (r *RevisionsMongoRepository) DeleteVersion(ctx context.Context, formID string, version int) error {
+	_, err := r.Collection.DeleteOne(ctx, bson.M{"form_id": formID, "version": version})
+	return err
+}
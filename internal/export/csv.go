@@ -0,0 +1,55 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVWriter escribe la exportación en CSV (RFC 4180).
+type CSVWriter struct {
+	writer *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{writer: csv.NewWriter(w)}
+}
+
+func (c *CSVWriter) WriteHeader(columns []Column) error {
+	titles := make([]string, len(columns))
+	for i, column := range columns {
+		titles[i] = escapeFormula(column.Title)
+	}
+	return c.writer.Write(titles)
+}
+
+func (c *CSVWriter) WriteRow(values []string) error {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escapeFormula(value)
+	}
+	if err := c.writer.Write(escaped); err != nil {
+		return err
+	}
+	// Se vacía el buffer en cada fila para que la descarga avance.
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *CSVWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// escapeFormula evita que las hojas de cálculo interpreten como fórmula un
+// texto respondido por el usuario (CSV injection). Los números se mantienen.
+func escapeFormula(value string) string {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return value
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	return "'" + value
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatXLSX   = "xlsx"
+	FormatNDJSON = "ndjson"
+)
+
+// Formats son los formatos de exportación soportados.
+var Formats = []string{FormatCSV, FormatXLSX, FormatNDJSON}
+
+// Column es una columna de la exportación. Numeric indica que sus valores son
+// números, para escribirlos como tales en los formatos que lo distinguen.
+type Column struct {
+	Title   string
+	Numeric bool
+}
+
+// Writer escribe una tabla fila a fila sobre un io.Writer, sin acumular las
+// filas en memoria. Close termina el documento pero no cierra el io.Writer.
+type Writer interface {
+	WriteHeader(columns []Column) error
+	WriteRow(values []string) error
+	Close() error
+}
+
+// New construye el Writer del formato indicado.
+func New(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatXLSX:
+		return NewXLSXWriter(w), nil
+	case FormatNDJSON:
+		return NewNDJSONWriter(w), nil
+	}
+	return nil, fmt.Errorf("export: unknown format %q", format)
+}
+
+// ContentType devuelve el tipo MIME del formato.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// NDJSONWriter escribe un objeto JSON por línea con los títulos de las
+// columnas como claves, en el orden de la cabecera.
+type NDJSONWriter struct {
+	writer  *bufio.Writer
+	columns []Column
+}
+
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{writer: bufio.NewWriter(w)}
+}
+
+func (n *NDJSONWriter) WriteHeader(columns []Column) error {
+	n.columns = columns
+	return nil
+}
+
+func (n *NDJSONWriter) WriteRow(values []string) error {
+
+	if len(values) != len(n.columns) {
+		return errors.New("export: row does not match the header")
+	}
+
+	n.writer.WriteByte('{')
+	for i, column := range n.columns {
+		if i > 0 {
+			n.writer.WriteByte(',')
+		}
+		key, _ := json.Marshal(column.Title)
+		n.writer.Write(key)
+		n.writer.WriteByte(':')
+		n.writer.Write(ndjsonValue(column, values[i]))
+	}
+	n.writer.WriteString("}\n")
+
+	return n.writer.Flush()
+}
+
+func (n *NDJSONWriter) Close() error {
+	return n.writer.Flush()
+}
+
+// ndjsonValue escribe los valores vacíos como null y los numéricos como número.
+func ndjsonValue(column Column, value string) []byte {
+	if value == "" {
+		return []byte("null")
+	}
+	if column.Numeric {
+		if _, err := strconv.ParseFloat(value, 64); err == nil && json.Valid([]byte(value)) {
+			return []byte(value)
+		}
+	}
+	encoded, _ := json.Marshal(value)
+	return encoded
+}
@@ -0,0 +1,280 @@
+package jsonschema
+
+import (
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/db/mongo/forms"
+	"fomrs/internal/utils"
+	"strconv"
+)
+
+// Campos del cuerpo de POST /v1/answers que describe FromForm.
+const (
+	FieldFormID     = "form_id"
+	FieldUserID     = "user_id"
+	FieldResponses  = "responses"
+	FieldQuestionID = "question_id"
+	FieldAnswer     = "answer"
+	FieldValues     = "values"
+	FieldMatrix     = "matrix"
+)
+
+// numberPattern acepta los números que admite el servidor en answer.
+const numberPattern = `^\s*[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?\s*$`
+
+// FromForm describe como JSON Schema el cuerpo de POST /v1/answers para el
+// formulario: form_id, user_id y responses. Cada entrada de responses es una
+// de las alternativas de oneOf, una por pregunta, que fija su question_id con
+// const y describe el valor en answer (texto), values (varias opciones) o
+// matrix (filas y columnas), que es donde lo espera el servidor.
+//
+// Las preguntas obligatorias se exigen con contains sobre responses, salvo las
+// que tienen visible_if, que solo lo son cuando se muestran; hidden y computed
+// son de solo lectura.
+func FromForm(form forms.FormModel) Schema {
+
+	questions := entities.NormalizeQuestions(form.Questions)
+
+	responses := &Schema{
+		Type:  SchemaType{"array"},
+		Items: &Schema{OneOf: make([]*Schema, 0, len(questions))},
+	}
+
+	for _, question := range questions {
+
+		responses.Items.OneOf = append(responses.Items.OneOf, questionSchema(question))
+
+		if question.Required && question.VisibleIf == nil && !utils.IsServerSide(utils.QuestionType(question.Type)) {
+			responses.AllOf = append(responses.AllOf, &Schema{
+				Contains: &Schema{
+					Type:       SchemaType{"object"},
+					Properties: Properties{{Name: FieldQuestionID, Schema: &Schema{Const: question.ID}}},
+					Required:   []string{FieldQuestionID},
+				},
+			})
+		}
+	}
+
+	return Schema{
+		Schema:      Draft,
+		ID:          form.ID,
+		Title:       form.Title,
+		Description: form.Description,
+		Type:        SchemaType{"object"},
+		Properties: Properties{
+			{Name: FieldFormID, Schema: &Schema{Type: SchemaType{"string"}, Const: form.ID}},
+			{Name: FieldUserID, Schema: &Schema{Type: SchemaType{"string"}}},
+			{Name: FieldResponses, Schema: responses},
+		},
+		Required: []string{FieldFormID, FieldResponses},
+		Sections: form.Sections,
+		Version:  form.CurrentVersion(),
+	}
+}
+
+// questionSchema describe una entrada de responses para la pregunta. Lo que no
+// tiene equivalente en JSON Schema (límites numéricos sobre texto, formatos de
+// fecha propios, min_date...) queda solo en x-metadata.
+func questionSchema(question entities.QuestionEntity) *Schema {
+
+	order := question.Order
+
+	field, value := valueSchema(question)
+
+	return &Schema{
+		Title:       question.Title,
+		Description: question.Description,
+		Type:        SchemaType{"object"},
+		Properties: Properties{
+			{Name: FieldQuestionID, Schema: &Schema{Const: question.ID}},
+			{Name: field, Schema: value},
+		},
+		Required:     []string{FieldQuestionID, field},
+		ReadOnly:     value.ReadOnly,
+		QuestionType: question.Type,
+		Section:      question.Section,
+		Order:        &order,
+		Metadata:     question.Metadata,
+		VisibleIf:    question.VisibleIf,
+	}
+}
+
+// valueSchema traduce el validador de la pregunta al campo de la entrada que
+// lleva la respuesta y a su esquema.
+func valueSchema(question entities.QuestionEntity) (string, *Schema) {
+
+	schema := &Schema{Type: SchemaType{"string"}}
+
+	validator, err := utils.NewValidator(utils.QuestionType(question.Type), question.Metadata)
+	if err != nil {
+		return FieldAnswer, schema
+	}
+
+	var rules utils.Rules
+	if withRules, ok := validator.(utils.RulesValidator); ok {
+		validator, rules = withRules.Validator, withRules.Rules
+	}
+
+	schema.MinLength = rules.MinLength
+	schema.MaxLength = rules.MaxLength
+	if rules.Pattern != nil {
+		schema.Pattern = rules.Pattern.String()
+	}
+
+	switch v := validator.(type) {
+
+	case utils.TextShortValidator:
+		maxLength := v.MaxLength
+		if maxLength == 0 {
+			maxLength = utils.DefaultTextShortMaxLength
+		}
+		schema.MaxLength = &maxLength
+
+	case utils.TextLongValidator:
+		minLength := v.EffectiveMinLength()
+		schema.MinLength = &minLength
+
+	case utils.EmailValidator:
+		schema.Format = "email"
+
+	case utils.URLValidator:
+		schema.Format = "uri"
+
+	case utils.DateValidator:
+		if v.Format == "" {
+			schema.Format = "date"
+		}
+
+	case utils.DateTimeValidator:
+		if v.Format == "" {
+			schema.Format = "date-time"
+		}
+
+	case utils.CountryValidator:
+		schema.Enum = stringsEnum(v.Countries)
+
+	case utils.BooleanValidator:
+		schema.Enum = stringsEnum([]string{"true", "false"})
+
+	case utils.NumberValidator:
+		// min, max y step van en x-metadata: answer es siempre un string.
+		schema.Pattern = numberPattern
+
+	case utils.ScaleValidator:
+		points := []string{}
+		for point := v.Min; point <= v.Max; point += max(v.Step, 1) {
+			points = append(points, strconv.Itoa(point))
+		}
+		schema.Enum = stringsEnum(points)
+
+	case utils.RadioValidator:
+		schema.Enum = stringsEnum(v.Options)
+
+	case utils.SelectValidator:
+		schema.Enum = stringsEnum(v.Options)
+
+	case utils.DropdownValidator:
+		schema.Enum = stringsEnum(v.Options)
+
+	case utils.CheckboxValidator:
+		arraySchema(schema, v.Options, v.Selections)
+		return FieldValues, schema
+
+	case utils.MultiDropdownValidator:
+		arraySchema(schema, v.Options, v.Selections)
+		return FieldValues, schema
+
+	case utils.GridValidator:
+		return FieldMatrix, matrixSchema(v)
+
+	case utils.ServerValidator:
+		schema.ReadOnly = true
+	}
+
+	return FieldAnswer, schema
+}
+
+// matrixSchema describe matrix: una entrada {row, columns} por fila, como
+// mucho una por fila y, con all_rows, todas.
+func matrixSchema(v utils.GridValidator) *Schema {
+
+	columns := &Schema{
+		Type:        SchemaType{"array"},
+		Items:       &Schema{Type: SchemaType{"string"}, Enum: stringsEnum(v.Columns)},
+		UniqueItems: true,
+	}
+	one := 1
+	columns.MinItems = &one
+	if !v.Multiple {
+		columns.MaxItems = &one
+	}
+
+	rows := len(v.Rows)
+	schema := &Schema{
+		Type: SchemaType{"array"},
+		Items: &Schema{
+			Type: SchemaType{"object"},
+			Properties: Properties{
+				{Name: "row", Schema: &Schema{Type: SchemaType{"string"}, Enum: stringsEnum(v.Rows)}},
+				{Name: "columns", Schema: columns},
+			},
+			Required: []string{"row", "columns"},
+		},
+		MaxItems: &rows,
+	}
+	if v.AllRows {
+		schema.MinItems = &rows
+	}
+
+	return schema
+}
+
+func arraySchema(schema *Schema, options []string, selections utils.Selections) {
+
+	schema.Type = SchemaType{"array"}
+	// Las reglas de texto se aplican a cada valor, no a la lista.
+	schema.Items = &Schema{
+		Type:      SchemaType{"string"},
+		Enum:      stringsEnum(options),
+		MinLength: schema.MinLength,
+		MaxLength: schema.MaxLength,
+		Pattern:   schema.Pattern,
+	}
+	schema.UniqueItems = true
+	schema.MinLength, schema.MaxLength, schema.Pattern = nil, nil, ""
+
+	if selections.Min > 0 {
+		minItems := selections.Min
+		schema.MinItems = &minItems
+	}
+	if selections.Max > 0 {
+		maxItems := selections.Max
+		schema.MaxItems = &maxItems
+	}
+}
+
+func stringsEnum(values []string) []any {
+	if len(values) == 0 {
+		return nil
+	}
+	enum := make([]any, len(values))
+	for i, value := range values {
+		enum[i] = value
+	}
+	return enum
+}
+
+// optionValue convierte un valor de enum en el texto de una opción.
+func optionValue(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		if v {
+			return "true", true
+		}
+		return "false", true
+	}
+	return "", false
+}
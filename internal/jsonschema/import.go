@@ -0,0 +1,424 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"fomrs/internal/api/v1/forms/domain/entities"
+	"fomrs/internal/utils"
+	"math"
+	"slices"
+)
+
+// ToQuestions reconstruye las preguntas de un formulario a partir de un JSON
+// Schema de tipo object. Acepta el esquema del cuerpo de las respuestas que
+// emite FromForm (una pregunta por alternativa de responses.items.oneOf) y un
+// objeto con una propiedad por pregunta cuyo id es la clave.
+//
+// Si la pregunta trae las anotaciones x-question-type y x-metadata se usan tal
+// cual; si no, el tipo y la metadata se deducen de las palabras clave de JSON
+// Schema.
+func ToQuestions(schema Schema) ([]entities.QuestionEntity, error) {
+
+	if schema.Schema != "" && schema.Schema != Draft {
+		return nil, fmt.Errorf("unsupported $schema %q: only %s is supported", schema.Schema, Draft)
+	}
+
+	if schema.Ref != "" {
+		return nil, errors.New("$ref is not supported")
+	}
+
+	if len(schema.Type) > 0 && !schema.Is("object") {
+		return nil, errors.New("schema type must be object")
+	}
+
+	if responses := schema.Properties.Get(FieldResponses); responses != nil && responses.Is("array") {
+		return responsesQuestions(responses)
+	}
+
+	if len(schema.Properties) == 0 {
+		return nil, errors.New("schema must declare at least one property")
+	}
+
+	questions := make([]entities.QuestionEntity, 0, len(schema.Properties))
+
+	for i, property := range schema.Properties {
+		if property.Name == "" {
+			return nil, errors.New("property name is required")
+		}
+		question, err := buildQuestion(property.Name, property.Schema, property.Schema, slices.Contains(schema.Required, property.Name), i)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", property.Name, err)
+		}
+		questions = append(questions, question)
+	}
+
+	return questions, nil
+}
+
+// responsesQuestions reconstruye las preguntas del esquema de responses: cada
+// alternativa de oneOf es una pregunta y las obligatorias son las que exige
+// algún contains de allOf.
+func responsesQuestions(responses *Schema) ([]entities.QuestionEntity, error) {
+
+	if responses.Items == nil || len(responses.Items.OneOf) == 0 {
+		return nil, errors.New("responses must declare items with oneOf, one per question")
+	}
+
+	required := []string{}
+	for _, schema := range responses.AllOf {
+		if schema.Contains == nil {
+			continue
+		}
+		if id, ok := questionID(schema.Contains); ok {
+			required = append(required, id)
+		}
+	}
+
+	questions := make([]entities.QuestionEntity, 0, len(responses.Items.OneOf))
+
+	for i, item := range responses.Items.OneOf {
+
+		id, ok := questionID(item)
+		if !ok {
+			return nil, fmt.Errorf("responses item %d: %s must declare a string const", i, FieldQuestionID)
+		}
+
+		field, value := responseValue(item)
+		if value == nil {
+			return nil, fmt.Errorf("question %s: must declare %s, %s or %s", id, FieldAnswer, FieldValues, FieldMatrix)
+		}
+
+		if field == FieldMatrix && (item.QuestionType == "" || item.Metadata == nil) {
+			var err error
+			if value, err = matrixObject(value); err != nil {
+				return nil, fmt.Errorf("question %s: %w", id, err)
+			}
+		}
+
+		question, err := buildQuestion(id, item, value, slices.Contains(required, id), i)
+		if err != nil {
+			return nil, fmt.Errorf("question %s: %w", id, err)
+		}
+		questions = append(questions, question)
+	}
+
+	return questions, nil
+}
+
+// questionID lee el const de question_id de una entrada de responses.
+func questionID(schema *Schema) (string, bool) {
+
+	property := schema.Properties.Get(FieldQuestionID)
+	if property == nil {
+		return "", false
+	}
+
+	id, ok := property.Const.(string)
+	return id, ok && id != ""
+}
+
+// responseValue devuelve el campo de la entrada que lleva la respuesta.
+func responseValue(item *Schema) (string, *Schema) {
+	for _, field := range []string{FieldAnswer, FieldValues, FieldMatrix} {
+		if value := item.Properties.Get(field); value != nil {
+			return field, value
+		}
+	}
+	return "", nil
+}
+
+// matrixObject convierte el esquema de matrix ({row, columns} por fila) en el
+// objeto con una propiedad por fila del que se deduce la matriz.
+func matrixObject(schema *Schema) (*Schema, error) {
+
+	if schema.Items == nil {
+		return nil, errors.New("matrix must declare items")
+	}
+
+	row := schema.Items.Properties.Get("row")
+	columns := schema.Items.Properties.Get("columns")
+	if row == nil || len(row.Enum) == 0 || columns == nil || columns.Items == nil {
+		return nil, errors.New("matrix items must declare row with enum and columns with items")
+	}
+
+	rows, err := enumOptions(row.Enum)
+	if err != nil {
+		return nil, err
+	}
+
+	column := columns.Items
+	if columns.MaxItems == nil || *columns.MaxItems > 1 {
+		column = &Schema{Type: SchemaType{"array"}, Items: columns.Items}
+	}
+
+	object := &Schema{Type: SchemaType{"object"}}
+	for _, name := range rows {
+		object.Properties = append(object.Properties, Property{Name: name, Schema: column})
+	}
+	if schema.MinItems != nil && *schema.MinItems >= len(rows) {
+		object.Required = rows
+	}
+
+	return object, nil
+}
+
+// buildQuestion crea la pregunta con id a partir de las anotaciones de
+// annotated y, si no trae x-question-type y x-metadata, deduce el tipo de
+// value.
+func buildQuestion(id string, annotated *Schema, value *Schema, required bool, index int) (entities.QuestionEntity, error) {
+
+	if annotated.Ref != "" || value.Ref != "" {
+		return entities.QuestionEntity{}, errors.New("$ref is not supported")
+	}
+
+	question := entities.QuestionEntity{
+		ID:          id,
+		Title:       annotated.Title,
+		Description: annotated.Description,
+		Required:    required,
+		Section:     annotated.Section,
+		Order:       index,
+		VisibleIf:   annotated.VisibleIf,
+	}
+
+	if question.Title == "" {
+		question.Title = id
+	}
+
+	if annotated.Order != nil {
+		question.Order = *annotated.Order
+	}
+
+	if annotated.QuestionType != "" && annotated.Metadata != nil {
+		question.Type = annotated.QuestionType
+		question.Metadata = annotated.Metadata
+		return question, nil
+	}
+
+	questionType, metadata, err := inferQuestion(value)
+	if err != nil {
+		return question, err
+	}
+
+	if annotated.QuestionType != "" {
+		questionType = utils.QuestionType(annotated.QuestionType)
+	}
+
+	// minLength, pattern... se leen de cualquier esquema; se descartan las que
+	// el tipo deducido no admite como regla.
+	for _, rule := range utils.RuleKeys {
+		if !utils.AppliesRule(questionType, rule) {
+			delete(metadata, rule)
+		}
+	}
+
+	question.Type = string(questionType)
+	question.Metadata = metadata
+
+	return question, nil
+}
+
+// inferQuestion deduce el tipo de pregunta y su metadata de las palabras clave
+// de JSON Schema.
+func inferQuestion(schema *Schema) (utils.QuestionType, map[string]any, error) {
+
+	metadata := map[string]any{}
+
+	if schema.MinLength != nil {
+		metadata[utils.RuleMinLength] = *schema.MinLength
+	}
+	if schema.MaxLength != nil {
+		metadata[utils.RuleMaxLength] = *schema.MaxLength
+	}
+	if schema.Pattern != "" {
+		metadata[utils.RulePattern] = schema.Pattern
+	}
+
+	switch {
+
+	// En answer los números y booleanos van como texto.
+	case schema.Pattern == numberPattern:
+		delete(metadata, utils.RulePattern)
+		return utils.QuestionTypeNumber, metadata, nil
+
+	case schema.Is("string") && isBooleanEnum(schema.Enum):
+		return utils.QuestionTypeBoolean, metadata, nil
+
+	case schema.Is("boolean"):
+		return utils.QuestionTypeBoolean, metadata, nil
+
+	case schema.Is("integer") || schema.Is("number"):
+		if len(schema.Enum) > 0 {
+			return enumQuestion(schema.Enum, metadata)
+		}
+		if schema.Minimum != nil {
+			metadata[utils.RuleMin] = *schema.Minimum
+		}
+		if schema.Maximum != nil {
+			metadata[utils.RuleMax] = *schema.Maximum
+		}
+		if schema.MultipleOf != nil {
+			metadata[utils.MetadataStep] = *schema.MultipleOf
+		}
+		if schema.Is("integer") {
+			return utils.QuestionTypeInteger, metadata, nil
+		}
+		return utils.QuestionTypeNumber, metadata, nil
+
+	case schema.Is("array"):
+		if schema.Items == nil || len(schema.Items.Enum) == 0 {
+			return "", nil, errors.New("arrays must declare items with enum")
+		}
+		options, err := enumOptions(schema.Items.Enum)
+		if err != nil {
+			return "", nil, err
+		}
+		metadata[utils.MetadataOptions] = options
+		if schema.MinItems != nil {
+			metadata[utils.MetadataMinSelections] = *schema.MinItems
+		}
+		if schema.MaxItems != nil {
+			metadata[utils.MetadataMaxSelections] = *schema.MaxItems
+		}
+		return utils.QuestionTypeCheckbox, metadata, nil
+
+	case schema.Is("object"):
+		return matrixQuestion(schema)
+
+	case len(schema.Enum) > 0:
+		options, err := enumOptions(schema.Enum)
+		if err != nil {
+			return "", nil, err
+		}
+		metadata[utils.MetadataOptions] = options
+		return utils.QuestionTypeRadio, metadata, nil
+
+	case len(schema.Type) > 0 && !schema.Is("string"):
+		return "", nil, fmt.Errorf("unsupported type %v", []string(schema.Type))
+	}
+
+	switch schema.Format {
+	case "email", "idn-email":
+		return utils.QuestionTypeTextEmail, metadata, nil
+	case "uri", "iri", "url":
+		return utils.QuestionTypeURL, metadata, nil
+	case "date":
+		return utils.QuestionTypeDate, metadata, nil
+	case "date-time":
+		return utils.QuestionTypeDateTime, metadata, nil
+	case "time":
+		return utils.QuestionTypeTime, metadata, nil
+	}
+
+	if schema.MaxLength != nil {
+		return utils.QuestionTypeTextShort, metadata, nil
+	}
+
+	return utils.QuestionTypeText, metadata, nil
+}
+
+// enumQuestion convierte un enum numérico en una escala si sus valores son
+// enteros equiespaciados y, si no, en una pregunta de opciones.
+func enumQuestion(enum []any, metadata map[string]any) (utils.QuestionType, map[string]any, error) {
+
+	points := make([]float64, 0, len(enum))
+	for _, value := range enum {
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			points = nil
+			break
+		}
+		points = append(points, number)
+	}
+
+	if len(points) > 1 && len(points) <= utils.MaxScalePoints {
+		step := points[1] - points[0]
+		arithmetic := step > 0
+		for i := 2; arithmetic && i < len(points); i++ {
+			arithmetic = points[i]-points[i-1] == step
+		}
+		if arithmetic {
+			metadata[utils.RuleMin] = points[0]
+			metadata[utils.RuleMax] = points[len(points)-1]
+			metadata[utils.MetadataStep] = step
+			return utils.QuestionTypeScale, metadata, nil
+		}
+	}
+
+	options, err := enumOptions(enum)
+	if err != nil {
+		return "", nil, err
+	}
+	metadata[utils.MetadataOptions] = options
+	return utils.QuestionTypeRadio, metadata, nil
+}
+
+// matrixQuestion convierte un objeto cuyas propiedades son enums en una
+// matriz: las propiedades son las filas y los valores, las columnas.
+func matrixQuestion(schema *Schema) (utils.QuestionType, map[string]any, error) {
+
+	if len(schema.Properties) == 0 {
+		return "", nil, errors.New("objects must declare properties")
+	}
+
+	rows := make([]string, 0, len(schema.Properties))
+	columns := []string{}
+	multiple := false
+
+	for _, row := range schema.Properties {
+
+		enum := row.Schema.Enum
+		if row.Schema.Is("array") {
+			multiple = true
+			if row.Schema.Items != nil {
+				enum = row.Schema.Items.Enum
+			}
+		}
+
+		if len(enum) == 0 {
+			return "", nil, fmt.Errorf("matrix row %s must declare enum", row.Name)
+		}
+
+		options, err := enumOptions(enum)
+		if err != nil {
+			return "", nil, err
+		}
+
+		for _, option := range options {
+			if !slices.Contains(columns, option) {
+				columns = append(columns, option)
+			}
+		}
+		rows = append(rows, row.Name)
+	}
+
+	allRows := true
+	for _, row := range rows {
+		allRows = allRows && slices.Contains(schema.Required, row)
+	}
+
+	return utils.QuestionTypeMatrix, map[string]any{
+		utils.MetadataRows:     rows,
+		utils.MetadataColumns:  columns,
+		utils.MetadataMultiple: multiple,
+		utils.MetadataAllRows:  allRows,
+	}, nil
+}
+
+func enumOptions(enum []any) ([]string, error) {
+
+	options := make([]string, 0, len(enum))
+	for _, value := range enum {
+		option, ok := optionValue(value)
+		if !ok {
+			return nil, errors.New("enum values must be strings, numbers or booleans")
+		}
+		options = append(options, option)
+	}
+	return options, nil
+}
+
+func isBooleanEnum(enum []any) bool {
+	return len(enum) == 2 && slices.ContainsFunc(enum, func(value any) bool { return value == "true" }) &&
+		slices.ContainsFunc(enum, func(value any) bool { return value == "false" })
+}
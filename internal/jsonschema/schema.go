@@ -0,0 +1,170 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fomrs/internal/api/v1/forms/domain/entities"
+)
+
+// Draft es el dialecto de JSON Schema que se emite y se acepta al importar.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema es el subconjunto de JSON Schema 2020-12 que se usa para describir un
+// formulario. Las claves x-* son anotaciones propias que permiten reconstruir
+// el formulario sin pérdidas al importarlo de nuevo.
+type Schema struct {
+	Schema      string     `json:"$schema,omitempty"`
+	ID          string     `json:"$id,omitempty"`
+	Ref         string     `json:"$ref,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Type        SchemaType `json:"type,omitempty"`
+	Format      string     `json:"format,omitempty"`
+	Const       any        `json:"const,omitempty"`
+	Enum        []any      `json:"enum,omitempty"`
+	ReadOnly    bool       `json:"readOnly,omitempty"`
+
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+
+	Minimum    *float64 `json:"minimum,omitempty"`
+	Maximum    *float64 `json:"maximum,omitempty"`
+	MultipleOf *float64 `json:"multipleOf,omitempty"`
+
+	Items       *Schema `json:"items,omitempty"`
+	MinItems    *int    `json:"minItems,omitempty"`
+	MaxItems    *int    `json:"maxItems,omitempty"`
+	UniqueItems bool    `json:"uniqueItems,omitempty"`
+	Contains    *Schema `json:"contains,omitempty"`
+
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	AllOf []*Schema `json:"allOf,omitempty"`
+
+	Properties           Properties `json:"properties,omitempty"`
+	Required             []string   `json:"required,omitempty"`
+	AdditionalProperties any        `json:"additionalProperties,omitempty"`
+
+	QuestionType string                   `json:"x-question-type,omitempty"`
+	Section      string                   `json:"x-section,omitempty"`
+	Order        *int                     `json:"x-order,omitempty"`
+	Metadata     map[string]any           `json:"x-metadata,omitempty"`
+	VisibleIf    *entities.Condition      `json:"x-visible-if,omitempty"`
+	Sections     []entities.SectionEntity `json:"x-sections,omitempty"`
+	Version      int                      `json:"x-version,omitempty"`
+}
+
+// Is indica si el esquema admite el tipo indicado (ignorando "null").
+func (s Schema) Is(schemaType string) bool {
+	for _, t := range s.Type {
+		if t == schemaType {
+			return true
+		}
+	}
+	return false
+}
+
+// SchemaType es la palabra clave type, que puede ser un tipo o una lista.
+type SchemaType []string
+
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t *SchemaType) UnmarshalJSON(data []byte) error {
+
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = SchemaType{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.New("type must be a string or an array of strings")
+	}
+	*t = list
+	return nil
+}
+
+// Property es una propiedad de un esquema de tipo object.
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// Properties conserva el orden de las propiedades, que en un formulario es el
+// orden de las preguntas.
+type Properties []Property
+
+// Get devuelve el esquema de la propiedad indicada o nil si no existe.
+func (p Properties) Get(name string) *Schema {
+	for _, property := range p {
+		if property.Name == name {
+			return property.Schema
+		}
+	}
+	return nil
+}
+
+func (p Properties) MarshalJSON() ([]byte, error) {
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for i, property := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(property.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(property.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (p *Properties) UnmarshalJSON(data []byte) error {
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return errors.New("properties must be an object")
+	}
+
+	properties := Properties{}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := token.(string)
+
+		var schema Schema
+		if err := decoder.Decode(&schema); err != nil {
+			return err
+		}
+		properties = append(properties, Property{Name: name, Schema: &schema})
+	}
+
+	*p = properties
+	return nil
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// ErrNotFound se devuelve cuando el objeto no existe en el almacenamiento.
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage guarda y recupera archivos por clave. Las implementaciones deben
+// copiar el contenido en streaming, sin cargarlo entero en memoria.
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Config selecciona y configura la implementación de Storage.
+type Config struct {
+	Driver string
+
+	LocalDir string
+
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// New construye el almacenamiento configurado.
+func New(config Config) (Storage, error) {
+	switch config.Driver {
+	case "", DriverLocal:
+		return NewLocalStorage(config.LocalDir)
+	case DriverS3:
+		return NewS3Storage(config.S3Endpoint, config.S3Region, config.S3Bucket, config.S3AccessKey, config.S3SecretKey)
+	}
+	return nil, fmt.Errorf("storage: unknown driver %q", config.Driver)
+}
+
+// cleanKey normaliza la clave y rechaza rutas que salgan de la raíz.
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	cleaned = strings.TrimPrefix(cleaned, "/")
+	if cleaned == "" || cleaned == "." {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return cleaned, nil
+}
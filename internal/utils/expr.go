@@ -0,0 +1,679 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Lenguaje de expresiones de los campos calculados. Es deliberadamente
+// pequeño: sin asignaciones, bucles ni acceso a nada que no sean las
+// variables que se le pasan, así que evaluarlo no tiene efectos secundarios.
+//
+//	literales:   12, 3.5, "texto", true, false, null
+//	operadores:  + - * / % ^  == != < <= > >=  && || !  (y paréntesis)
+//	funciones:   min, max, abs, round(x[, decimales]), floor, ceil, sqrt,
+//	             if(condición, sí, no), coalesce(a, b, ...), concat(a, b, ...)
+//
+// Los valores son float64, string, bool o nil.
+
+const (
+	// MaxExpressionLength limita el tamaño de una expresión.
+	MaxExpressionLength = 1000
+	// maxExpressionDepth limita el anidamiento para acotar la recursión.
+	maxExpressionDepth = 50
+)
+
+// Expression es una expresión ya analizada, lista para evaluarse.
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+type exprNode interface {
+	eval(vars map[string]any) (any, error)
+}
+
+// ParseExpression analiza la expresión y devuelve error si la sintaxis no es
+// válida o usa funciones desconocidas.
+func ParseExpression(source string) (*Expression, error) {
+
+	if strings.TrimSpace(source) == "" {
+		return nil, errors.New("expression must not be empty")
+	}
+
+	if len(source) > MaxExpressionLength {
+		return nil, fmt.Errorf("expression must have at most %d characters", MaxExpressionLength)
+	}
+
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("expression: unexpected %q at position %d", p.peek().text, p.peek().pos)
+	}
+
+	return &Expression{source: source, root: root}, nil
+}
+
+func (e *Expression) String() string { return e.source }
+
+// Identifiers devuelve las variables que usa la expresión, sin repetir.
+func (e *Expression) Identifiers() []string {
+
+	var names []string
+	seen := map[string]bool{}
+
+	var walk func(node exprNode)
+	walk = func(node exprNode) {
+		switch n := node.(type) {
+		case identNode:
+			if !seen[string(n)] {
+				seen[string(n)] = true
+				names = append(names, string(n))
+			}
+		case unaryNode:
+			walk(n.operand)
+		case binaryNode:
+			walk(n.left)
+			walk(n.right)
+		case callNode:
+			for _, arg := range n.args {
+				walk(arg)
+			}
+		}
+	}
+	walk(e.root)
+
+	return names
+}
+
+// Evaluate calcula la expresión con los valores de las variables. Una
+// variable ausente vale nil.
+func (e *Expression) Evaluate(vars map[string]any) (any, error) {
+	return e.root.eval(vars)
+}
+
+// FormatValue convierte el resultado en el texto que se guarda como respuesta.
+// Devuelve false si el resultado es nil.
+func FormatValue(value any) (string, bool) {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case string:
+		return v, true
+	}
+	return "", false
+}
+
+// ---------- tokens ----------
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var exprOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "+", "-", "*", "/", "%", "^", "<", ">", "!", "(", ")", ","}
+
+func tokenize(source string) ([]token, error) {
+
+	var tokens []token
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+
+		case r == '"' || r == '\'':
+			start := i
+			var text strings.Builder
+			i++
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				text.WriteRune(runes[i])
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("expression: unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, text: text.String(), pos: start})
+
+		default:
+			matched := false
+			for _, op := range exprOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("expression: unexpected %q at position %d", string(r), i)
+			}
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+// ---------- parser ----------
+
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *exprParser) peek() token { return p.tokens[p.pos] }
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *exprParser) accept(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		t := p.peek()
+		return fmt.Errorf("expression: expected %q at position %d", op, t.pos)
+	}
+	return nil
+}
+
+// parseBinary resuelve un nivel de precedencia con operadores asociativos por
+// la izquierda.
+func (p *exprParser) parseBinary(depth int, operand func(int) (exprNode, error), ops ...string) (exprNode, error) {
+
+	left, err := operand(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := operand(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseOr(depth int) (exprNode, error) {
+	if depth > maxExpressionDepth {
+		return nil, errors.New("expression is nested too deeply")
+	}
+	return p.parseBinary(depth, p.parseAnd, "||")
+}
+
+func (p *exprParser) parseAnd(depth int) (exprNode, error) {
+	return p.parseBinary(depth, p.parseComparison, "&&")
+}
+
+func (p *exprParser) parseComparison(depth int) (exprNode, error) {
+
+	left, err := p.parseAdditive(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	op, ok := p.accept("==", "!=", "<=", ">=", "<", ">")
+	if !ok {
+		return left, nil
+	}
+
+	right, err := p.parseAdditive(depth)
+	if err != nil {
+		return nil, err
+	}
+	return binaryNode{op: op, left: left, right: right}, nil
+}
+
+func (p *exprParser) parseAdditive(depth int) (exprNode, error) {
+	return p.parseBinary(depth, p.parseMultiplicative, "+", "-")
+}
+
+func (p *exprParser) parseMultiplicative(depth int) (exprNode, error) {
+	return p.parseBinary(depth, p.parseUnary, "*", "/", "%")
+}
+
+func (p *exprParser) parseUnary(depth int) (exprNode, error) {
+
+	if depth > maxExpressionDepth {
+		return nil, errors.New("expression is nested too deeply")
+	}
+
+	if op, ok := p.accept("-", "!"); ok {
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{op: op, operand: operand}, nil
+	}
+
+	return p.parsePower(depth)
+}
+
+// parsePower es asociativo por la derecha: 2^3^2 = 2^(3^2).
+func (p *exprParser) parsePower(depth int) (exprNode, error) {
+
+	base, err := p.parsePrimary(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := p.accept("^"); ok {
+		exponent, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return binaryNode{op: "^", left: base, right: exponent}, nil
+	}
+
+	return base, nil
+}
+
+func (p *exprParser) parsePrimary(depth int) (exprNode, error) {
+
+	t := p.next()
+
+	switch t.kind {
+	case tokenNumber:
+		number, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expression: invalid number %q at position %d", t.text, t.pos)
+		}
+		return literalNode{value: number}, nil
+
+	case tokenString:
+		return literalNode{value: t.text}, nil
+
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "null":
+			return literalNode{value: nil}, nil
+		}
+
+		if _, ok := p.accept("("); !ok {
+			return identNode(t.text), nil
+		}
+
+		function, ok := exprFunctions[t.text]
+		if !ok {
+			return nil, fmt.Errorf("expression: unknown function %q", t.text)
+		}
+
+		var args []exprNode
+		if _, ok := p.accept(")"); !ok {
+			for {
+				arg, err := p.parseOr(depth + 1)
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if _, ok := p.accept(","); ok {
+					continue
+				}
+				if err := p.expect(")"); err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+
+		if len(args) < function.minArgs || (function.maxArgs >= 0 && len(args) > function.maxArgs) {
+			return nil, fmt.Errorf("expression: wrong number of arguments for %s", t.text)
+		}
+
+		return callNode{name: t.text, function: function, args: args}, nil
+
+	case tokenOperator:
+		if t.text == "(" {
+			inner, err := p.parseOr(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		}
+	}
+
+	if t.kind == tokenEOF {
+		return nil, errors.New("expression: unexpected end")
+	}
+	return nil, fmt.Errorf("expression: unexpected %q at position %d", t.text, t.pos)
+}
+
+// ---------- evaluación ----------
+
+type literalNode struct{ value any }
+
+func (n literalNode) eval(map[string]any) (any, error) { return n.value, nil }
+
+type identNode string
+
+func (n identNode) eval(vars map[string]any) (any, error) { return vars[string(n)], nil }
+
+type unaryNode struct {
+	op      string
+	operand exprNode
+}
+
+func (n unaryNode) eval(vars map[string]any) (any, error) {
+
+	value, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "!" {
+		b, ok := value.(bool)
+		if !ok {
+			return nil, errors.New("expression: ! requires a boolean")
+		}
+		return !b, nil
+	}
+
+	number, err := toNumber(value, n.op)
+	if err != nil {
+		return nil, err
+	}
+	return -number, nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n binaryNode) eval(vars map[string]any) (any, error) {
+
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	// && y || cortocircuitan.
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expression: %s requires booleans", n.op)
+		}
+		if (n.op == "&&" && !l) || (n.op == "||" && l) {
+			return l, nil
+		}
+		right, err := n.right.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expression: %s requires booleans", n.op)
+		}
+		return r, nil
+	}
+
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	}
+
+	l, err := toNumber(left, n.op)
+	if err != nil {
+		return nil, err
+	}
+	r, err := toNumber(right, n.op)
+	if err != nil {
+		return nil, err
+	}
+
+	var result float64
+	switch n.op {
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	case "+":
+		result = l + r
+	case "-":
+		result = l - r
+	case "*":
+		result = l * r
+	case "/":
+		if r == 0 {
+			return nil, errors.New("expression: division by zero")
+		}
+		result = l / r
+	case "%":
+		if r == 0 {
+			return nil, errors.New("expression: division by zero")
+		}
+		result = math.Mod(l, r)
+	case "^":
+		result = math.Pow(l, r)
+	}
+
+	return checkNumber(result)
+}
+
+type callNode struct {
+	name     string
+	function exprFunction
+	args     []exprNode
+}
+
+func (n callNode) eval(vars map[string]any) (any, error) {
+
+	// if solo evalúa la rama elegida.
+	if n.name == "if" {
+		condition, err := n.args[0].eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		b, ok := condition.(bool)
+		if !ok {
+			return nil, errors.New("expression: if requires a boolean condition")
+		}
+		if b {
+			return n.args[1].eval(vars)
+		}
+		return n.args[2].eval(vars)
+	}
+
+	args := make([]any, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+
+	return n.function.call(args)
+}
+
+type exprFunction struct {
+	minArgs int
+	maxArgs int // -1 = sin límite
+	call    func(args []any) (any, error)
+}
+
+var exprFunctions = map[string]exprFunction{
+	"min":      {1, -1, func(args []any) (any, error) { return foldNumbers("min", args, math.Min) }},
+	"max":      {1, -1, func(args []any) (any, error) { return foldNumbers("max", args, math.Max) }},
+	"abs":      {1, 1, mathFunction("abs", math.Abs)},
+	"floor":    {1, 1, mathFunction("floor", math.Floor)},
+	"ceil":     {1, 1, mathFunction("ceil", math.Ceil)},
+	"sqrt":     {1, 1, mathFunction("sqrt", math.Sqrt)},
+	"round":    {1, 2, round},
+	"if":       {3, 3, nil},
+	"coalesce": {1, -1, coalesce},
+	"concat":   {1, -1, concat},
+}
+
+func mathFunction(name string, fn func(float64) float64) func(args []any) (any, error) {
+	return func(args []any) (any, error) {
+		number, err := toNumber(args[0], name)
+		if err != nil {
+			return nil, err
+		}
+		return checkNumber(fn(number))
+	}
+}
+
+func foldNumbers(name string, args []any, fn func(float64, float64) float64) (any, error) {
+
+	result, err := toNumber(args[0], name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, arg := range args[1:] {
+		number, err := toNumber(arg, name)
+		if err != nil {
+			return nil, err
+		}
+		result = fn(result, number)
+	}
+	return result, nil
+}
+
+func round(args []any) (any, error) {
+
+	number, err := toNumber(args[0], "round")
+	if err != nil {
+		return nil, err
+	}
+
+	digits := 0.0
+	if len(args) == 2 {
+		if digits, err = toNumber(args[1], "round"); err != nil {
+			return nil, err
+		}
+		if digits < 0 || digits > 15 || digits != math.Trunc(digits) {
+			return nil, errors.New("expression: round digits must be an integer between 0 and 15")
+		}
+	}
+
+	factor := math.Pow(10, digits)
+	return checkNumber(math.Round(number*factor) / factor)
+}
+
+func coalesce(args []any) (any, error) {
+	for _, arg := range args {
+		if arg != nil {
+			return arg, nil
+		}
+	}
+	return nil, nil
+}
+
+func concat(args []any) (any, error) {
+
+	var text strings.Builder
+	for _, arg := range args {
+		if value, ok := FormatValue(arg); ok {
+			text.WriteString(value)
+		}
+	}
+	return text.String(), nil
+}
+
+func toNumber(value any, op string) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case nil:
+		return 0, fmt.Errorf("expression: %s on a missing value", op)
+	}
+	return 0, fmt.Errorf("expression: %s requires numbers", op)
+}
+
+func checkNumber(number float64) (any, error) {
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return nil, errors.New("expression: result is not a finite number")
+	}
+	return number, nil
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseExpression(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		source  string
+		wantErr bool
+	}{
+		{name: "number", source: "12"},
+		{name: "arithmetic", source: "a + b * 2"},
+		{name: "comparison and logic", source: "a >= 18 && !(b == 'x')"},
+		{name: "function call", source: "round(a / 3, 2)"},
+		{name: "variadic function", source: "concat(a, ' ', b, ' ', c)"},
+		{name: "empty", source: "  ", wantErr: true},
+		{name: "unexpected end", source: "a +", wantErr: true},
+		{name: "unclosed parenthesis", source: "(a + 1", wantErr: true},
+		{name: "unterminated string", source: "'abc", wantErr: true},
+		{name: "unknown function", source: "exec(a)", wantErr: true},
+		{name: "too few arguments", source: "if(a, b)", wantErr: true},
+		{name: "too many arguments", source: "abs(a, b)", wantErr: true},
+		{name: "chained comparison", source: "a < b < c", wantErr: true},
+		{name: "unknown character", source: "a $ b", wantErr: true},
+		{name: "invalid number", source: "1.2.3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExpression(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseExpression(%q) error = %v, wantErr %v", tt.source, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpressionEvaluate(t *testing.T) {
+
+	vars := map[string]any{"a": 7.0, "b": 2.0, "name": "Ana", "ok": true}
+
+	tests := []struct {
+		name    string
+		source  string
+		want    any
+		wantErr bool
+	}{
+		{name: "precedence", source: "1 + 2 * 3", want: 7.0},
+		{name: "parentheses", source: "(1 + 2) * 3", want: 9.0},
+		{name: "left associative", source: "10 - 4 - 3", want: 3.0},
+		{name: "power right associative", source: "2 ^ 3 ^ 2", want: 512.0},
+		{name: "unary minus binds looser than power", source: "-2 ^ 2", want: -4.0},
+		{name: "modulo", source: "a % b", want: 1.0},
+		{name: "variables", source: "a * b", want: 14.0},
+		{name: "comparison", source: "a > b", want: true},
+		{name: "equality of strings", source: "name == \"Ana\"", want: true},
+		{name: "not", source: "!ok", want: false},
+		{name: "short circuit and", source: "false && missing", want: false},
+		{name: "short circuit or", source: "true || missing", want: true},
+		{name: "if chooses branch", source: "if(a > 5, 'high', 'low')", want: "high"},
+		{name: "if does not evaluate other branch", source: "if(ok, 1, 1 / 0)", want: 1.0},
+		{name: "min and max", source: "max(a, b, 10) - min(a, b)", want: 8.0},
+		{name: "round with digits", source: "round(a / 3, 2)", want: 2.33},
+		{name: "floor ceil abs", source: "floor(2.7) + ceil(2.1) + abs(-1)", want: 6.0},
+		{name: "coalesce skips missing", source: "coalesce(missing, b)", want: 2.0},
+		{name: "concat formats numbers", source: "concat(name, ' ', a)", want: "Ana 7"},
+		{name: "missing variable is null", source: "missing == null", want: true},
+		{name: "division by zero", source: "a / 0", wantErr: true},
+		{name: "modulo by zero", source: "a % 0", wantErr: true},
+		{name: "arithmetic on missing value", source: "missing + 1", wantErr: true},
+		{name: "arithmetic on string", source: "name * 2", wantErr: true},
+		{name: "logic on numbers", source: "a && b", wantErr: true},
+		{name: "not a finite number", source: "sqrt(-1)", wantErr: true},
+		{name: "invalid round digits", source: "round(a, 1.5)", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			expression, err := ParseExpression(tt.source)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q) error = %v", tt.source, err)
+			}
+
+			got, err := expression.Evaluate(vars)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Evaluate(%q) error = %v, wantErr %v", tt.source, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Evaluate(%q) = %v, want %v", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpressionIdentifiers(t *testing.T) {
+
+	expression, err := ParseExpression("if(a > b, a, coalesce(c, b)) + 'a'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"a", "b", "c"}
+	if got := expression.Identifiers(); !slices.Equal(got, want) {
+		t.Errorf("Identifiers() = %v, want %v", got, want)
+	}
+}
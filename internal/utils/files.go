@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// Claves de metadata de las preguntas de tipo file.
+const (
+	// MetadataMaxSize es el tamaño máximo del archivo en bytes.
+	MetadataMaxSize = "max_size"
+	// MetadataAllowedMimeTypes lista los tipos MIME aceptados; admite comodines
+	// por familia como "image/*".
+	MetadataAllowedMimeTypes = "allowed_mime_types"
+)
+
+// FileLimits son las restricciones que se aplican al contenido de un archivo
+// subido, además de la extensión que ya valida FileValidator.
+type FileLimits struct {
+	MaxSize          int64
+	AllowedMimeTypes []string
+}
+
+func ParseFileLimits(metadata map[string]any) (FileLimits, error) {
+
+	var limits FileLimits
+
+	maxSize, ok, err := MetadataInt(metadata, MetadataMaxSize)
+	if err != nil {
+		return limits, err
+	}
+	if ok {
+		if maxSize <= 0 {
+			return limits, errors.New(MetadataMaxSize + " must be greater than 0")
+		}
+		limits.MaxSize = int64(maxSize)
+	}
+
+	mimeTypes, _, err := MetadataStrings(metadata, MetadataAllowedMimeTypes)
+	if err != nil {
+		return limits, err
+	}
+
+	for _, mimeType := range mimeTypes {
+		normalized := strings.ToLower(strings.TrimSpace(mimeType))
+		if !strings.HasSuffix(normalized, "/*") {
+			if _, _, err := mime.ParseMediaType(normalized); err != nil {
+				return limits, fmt.Errorf("%s: invalid mime type %q", MetadataAllowedMimeTypes, mimeType)
+			}
+		}
+		limits.AllowedMimeTypes = append(limits.AllowedMimeTypes, normalized)
+	}
+
+	return limits, nil
+}
+
+// EffectiveMaxSize aplica el límite global cuando la pregunta no define uno
+// o define uno mayor.
+func (l FileLimits) EffectiveMaxSize(global int64) int64 {
+	if l.MaxSize > 0 && (global <= 0 || l.MaxSize < global) {
+		return l.MaxSize
+	}
+	return global
+}
+
+// AllowsMimeType indica si el tipo MIME detectado está permitido. Sin lista
+// configurada se acepta cualquier tipo.
+func (l FileLimits) AllowsMimeType(contentType string) bool {
+
+	if len(l.AllowedMimeTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range l.AllowedMimeTypes {
+		if allowed == mediaType {
+			return true
+		}
+		if family, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mediaType, family+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,510 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Claves de metadata de los tipos con formato configurable.
+const (
+	// MetadataFormat es el formato de entrada de date, time y datetime con los
+	// tokens YYYY, YY, MM, M, DD, D, HH, hh, mm, ss, A y Z. En time la zona (Z)
+	// solo se valida: la hora se guarda tal cual, sin convertirla ni conservar
+	// la zona.
+	MetadataFormat = "format"
+	// MetadataTimezone es la zona IANA que se asume en un datetime sin zona.
+	MetadataTimezone = "timezone"
+	// MetadataDefaultRegion es el país (ISO alfa-2) de los teléfonos sin prefijo internacional.
+	MetadataDefaultRegion = "default_region"
+	// MetadataSchemes restringe los esquemas aceptados en url.
+	MetadataSchemes = "schemes"
+	// MetadataCountries restringe los países aceptados en country.
+	MetadataCountries = "countries"
+)
+
+// Formatos canónicos con los que se guardan las respuestas normalizadas.
+const (
+	CanonicalDate     = "2006-01-02"
+	CanonicalTime     = "15:04:05"
+	CanonicalDateTime = time.RFC3339
+)
+
+var DefaultURLSchemes = []string{"http", "https"}
+
+// Normalizer lo implementan los validadores que convierten la respuesta a una
+// forma canónica antes de guardarla (p. ej. un teléfono a E.164).
+type Normalizer interface {
+	Normalize(value string) (string, bool)
+}
+
+// Normalize devuelve la forma canónica del valor; los validadores sin
+// normalización lo devuelven sin cambios.
+func Normalize(validator Validator, value string) string {
+
+	if normalizer, ok := validator.(Normalizer); ok {
+		if normalized, ok := normalizer.Normalize(value); ok {
+			return normalized
+		}
+	}
+	return value
+}
+
+// NormalizeValues devuelve la forma canónica de cada valor.
+func NormalizeValues(validator Validator, values []string) []string {
+	normalized := make([]string, len(values))
+	for i, value := range values {
+		normalized[i] = Normalize(validator, value)
+	}
+	return normalized
+}
+
+// layoutTokens traduce los tokens del formato a un layout de Go. Los más
+// largos van primero para que "YYYY" no se lea como dos "YY". No hay "H": Go
+// no tiene una hora de 24 h sin relleno distinta de "15", que ya admite una o
+// dos cifras, así que "HH" cubre "9:05" y "09:05".
+var layoutTokens = []struct{ token, layout string }{
+	{"YYYY", "2006"}, {"YY", "06"},
+	{"MM", "01"}, {"M", "1"},
+	{"DD", "02"}, {"D", "2"},
+	{"HH", "15"}, {"hh", "03"},
+	{"mm", "04"}, {"ss", "05"},
+	{"A", "PM"}, {"Z", "Z07:00"},
+}
+
+// layoutReference es la fecha con la que se comprueba que el texto literal de
+// un formato no se confunda con un elemento del layout de Go.
+var layoutReference = time.Date(2031, time.November, 28, 19, 47, 53, 0, time.FixedZone("", 5*3600+30*60))
+
+// ParseLayout convierte un formato como "DD/MM/YYYY" en un layout de Go. El
+// texto literal va entre corchetes ("DD [de] MM") o escapado con "\"; la "T"
+// de ISO 8601 ("YYYY-MM-DDTHH:mm") se acepta sin escapar.
+func ParseLayout(format string) (string, error) {
+
+	if strings.TrimSpace(format) == "" {
+		return "", errors.New(MetadataFormat + " must not be empty")
+	}
+
+	// expected es lo que debería producir el layout con layoutReference.
+	var layout, expected strings.Builder
+	literal := func(text string) {
+		layout.WriteString(text)
+		expected.WriteString(text)
+	}
+
+	rest := format
+
+	for rest != "" {
+		matched := false
+		for _, t := range layoutTokens {
+			if strings.HasPrefix(rest, t.token) {
+				layout.WriteString(t.layout)
+				expected.WriteString(layoutReference.Format(t.layout))
+				rest = rest[len(t.token):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		switch char := rest[:1]; {
+		case char == "[":
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return "", fmt.Errorf("%s: unclosed [ in %q", MetadataFormat, format)
+			}
+			literal(rest[1:end])
+			rest = rest[end+1:]
+
+		case char == "\\":
+			if len(rest) < 2 {
+				return "", fmt.Errorf("%s: nothing to escape at the end of %q", MetadataFormat, format)
+			}
+			_, size := utf8.DecodeRuneInString(rest[1:])
+			literal(rest[1 : 1+size])
+			rest = rest[1+size:]
+
+		case char == "H":
+			return "", fmt.Errorf("%s: H is not supported in %q, use HH (it accepts one or two digits)", MetadataFormat, format)
+
+		case char == "T":
+			literal(char)
+			rest = rest[1:]
+
+		case strings.ContainsAny(char, "0123456789") || (char >= "a" && char <= "z") || (char >= "A" && char <= "Z"):
+			return "", fmt.Errorf("%s: unexpected %q in %q (put literal text between [ ])", MetadataFormat, char, format)
+
+		default:
+			literal(char)
+			rest = rest[1:]
+		}
+	}
+
+	// Go no permite escapar texto en un layout: un literal como "Mon" o "1"
+	// se interpretaría como parte de la fecha.
+	if layoutReference.Format(layout.String()) != expected.String() {
+		return "", fmt.Errorf("%s: literal text in %q is not allowed in a date layout", MetadataFormat, format)
+	}
+
+	return layout.String(), nil
+}
+
+// metadataLayouts lee el formato de la metadata y su layout de Go. Sin formato
+// devuelve nil y el validador usa sus layouts por defecto.
+func metadataLayouts(metadata map[string]any) ([]string, string, error) {
+
+	format, ok, err := MetadataString(metadata, MetadataFormat)
+	if err != nil || !ok {
+		return nil, "", err
+	}
+
+	layout, err := ParseLayout(format)
+	if err != nil {
+		return nil, "", err
+	}
+	return []string{layout}, format, nil
+}
+
+// describeLayouts muestra el formato declarado o, si no hay, los layouts.
+func describeLayouts(format string, layouts []string) string {
+	if format != "" {
+		return format
+	}
+	return strings.Join(layouts, " or ")
+}
+
+func parseWithLayouts(value string, layouts []string, location *time.Location) (time.Time, bool) {
+
+	value = strings.TrimSpace(value)
+
+	for _, layout := range layouts {
+		if parsed, err := time.ParseInLocation(layout, value, location); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Fecha (YYYY-MM-DD por defecto, configurable con format; se guarda como YYYY-MM-DD)
+type DateValidator struct {
+	Layouts []string
+	// Format es el formato declarado en la metadata, para los mensajes.
+	Format string
+}
+
+func (d DateValidator) layouts() []string {
+	if len(d.Layouts) > 0 {
+		return d.Layouts
+	}
+	return []string{CanonicalDate}
+}
+
+func (d DateValidator) Name() string { return string(QuestionTypeDate) }
+func (d DateValidator) IsValid(value string) bool {
+	_, ok := d.Normalize(value)
+	return ok
+}
+
+func (d DateValidator) Normalize(value string) (string, bool) {
+	date, ok := parseWithLayouts(value, d.layouts(), time.UTC)
+	if !ok {
+		return "", false
+	}
+	return date.Format(CanonicalDate), true
+}
+
+func (d DateValidator) Description() string {
+	return "Date (" + describeLayouts(d.Format, d.layouts()) + ")"
+}
+
+// Hora del día (HH:mm o HH:mm:ss por defecto; se guarda como HH:mm:ss)
+type TimeValidator struct {
+	Layouts []string
+	// Format es el formato declarado en la metadata, para los mensajes.
+	Format string
+}
+
+var DefaultTimeLayouts = []string{"15:04", CanonicalTime}
+
+func (t TimeValidator) layouts() []string {
+	if len(t.Layouts) > 0 {
+		return t.Layouts
+	}
+	return DefaultTimeLayouts
+}
+
+func (t TimeValidator) Name() string { return string(QuestionTypeTime) }
+func (t TimeValidator) IsValid(value string) bool {
+	_, ok := t.Normalize(value)
+	return ok
+}
+
+func (t TimeValidator) Normalize(value string) (string, bool) {
+	parsed, ok := parseWithLayouts(value, t.layouts(), time.UTC)
+	if !ok {
+		return "", false
+	}
+	return parsed.Format(CanonicalTime), true
+}
+
+func (t TimeValidator) Description() string {
+	return "Time (" + describeLayouts(t.Format, t.layouts()) + ")"
+}
+
+// Fecha y hora (RFC 3339 por defecto; sin zona se asume Location; se guarda en UTC)
+type DateTimeValidator struct {
+	Layouts  []string
+	Format   string
+	Location *time.Location
+}
+
+func (d DateTimeValidator) layouts() []string {
+	if len(d.Layouts) > 0 {
+		return d.Layouts
+	}
+	return []string{CanonicalDateTime}
+}
+
+func (d DateTimeValidator) location() *time.Location {
+	if d.Location != nil {
+		return d.Location
+	}
+	return time.UTC
+}
+
+func (d DateTimeValidator) Name() string { return string(QuestionTypeDateTime) }
+func (d DateTimeValidator) IsValid(value string) bool {
+	_, ok := d.Normalize(value)
+	return ok
+}
+
+func (d DateTimeValidator) Normalize(value string) (string, bool) {
+	parsed, ok := parseWithLayouts(value, d.layouts(), d.location())
+	if !ok {
+		return "", false
+	}
+	return parsed.UTC().Format(CanonicalDateTime), true
+}
+
+func (d DateTimeValidator) Description() string {
+	return "Datetime (" + describeLayouts(d.Format, d.layouts()) + ", timezone " + d.location().String() + ")"
+}
+
+// Teléfono (E.164; sin prefijo internacional se usa DefaultRegion)
+type PhoneValidator struct {
+	DefaultRegion string
+}
+
+var (
+	e164Pattern     = regexp.MustCompile(`^\+[1-9]\d{6,14}$`)
+	phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "")
+)
+
+func (p PhoneValidator) Name() string { return string(QuestionTypePhone) }
+func (p PhoneValidator) IsValid(value string) bool {
+	_, ok := p.Normalize(value)
+	return ok
+}
+
+func (p PhoneValidator) Normalize(value string) (string, bool) {
+
+	number := phoneSeparators.Replace(strings.TrimSpace(value))
+
+	switch {
+	case strings.HasPrefix(number, "+"):
+	case strings.HasPrefix(number, "00"):
+		number = "+" + number[2:]
+	case p.DefaultRegion != "":
+		code := countryCallingCodes[p.DefaultRegion]
+		if !keepsTrunkPrefix[p.DefaultRegion] {
+			number = strings.TrimPrefix(number, "0")
+		}
+		// En la zona NANP (+1) el número nacional puede llevar el 1 delante.
+		if code == "1" && len(number) == 11 {
+			number = strings.TrimPrefix(number, "1")
+		}
+		number = "+" + code + number
+	default:
+		return "", false
+	}
+
+	if !e164Pattern.MatchString(number) {
+		return "", false
+	}
+	return number, true
+}
+
+func (p PhoneValidator) Description() string {
+	if p.DefaultRegion != "" {
+		return "Phone (E.164, default region " + p.DefaultRegion + ")"
+	}
+	return "Phone (E.164, e.g. +34600000000)"
+}
+
+// URL (absoluta, con host y esquema permitido; se guarda con esquema y host en minúsculas)
+type URLValidator struct {
+	Schemes []string
+}
+
+func (u URLValidator) schemes() []string {
+	if len(u.Schemes) > 0 {
+		return u.Schemes
+	}
+	return DefaultURLSchemes
+}
+
+func (u URLValidator) Name() string { return string(QuestionTypeURL) }
+func (u URLValidator) IsValid(value string) bool {
+	_, ok := u.Normalize(value)
+	return ok
+}
+
+func (u URLValidator) Normalize(value string) (string, bool) {
+
+	parsed, err := url.Parse(strings.TrimSpace(value))
+	if err != nil || parsed.Host == "" || parsed.Hostname() == "" {
+		return "", false
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	if !slices.Contains(u.schemes(), parsed.Scheme) {
+		return "", false
+	}
+
+	parsed.Host = strings.ToLower(parsed.Host)
+	return parsed.String(), true
+}
+
+func (u URLValidator) Description() string {
+	return "URL (" + strings.Join(u.schemes(), ", ") + ")"
+}
+
+// País (código ISO 3166-1 alfa-2; se guarda en mayúsculas)
+type CountryValidator struct {
+	Countries []string
+}
+
+func (c CountryValidator) Name() string { return string(QuestionTypeCountry) }
+func (c CountryValidator) IsValid(value string) bool {
+	_, ok := c.Normalize(value)
+	return ok
+}
+
+func (c CountryValidator) Normalize(value string) (string, bool) {
+
+	code := strings.ToUpper(strings.TrimSpace(value))
+
+	if !IsCountryCode(code) {
+		return "", false
+	}
+
+	if len(c.Countries) > 0 && !slices.Contains(c.Countries, code) {
+		return "", false
+	}
+	return code, true
+}
+
+func (c CountryValidator) Description() string {
+	if len(c.Countries) > 0 {
+		return "Country (one of: " + strings.Join(c.Countries, ", ") + ")"
+	}
+	return "Country (ISO 3166-1 alpha-2 code)"
+}
+
+// metadataCountryCodes lee una lista de códigos de país y los pasa a mayúsculas.
+func metadataCountryCodes(metadata map[string]any, key string) ([]string, error) {
+
+	codes, _, err := MetadataStrings(metadata, key)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]string, len(codes))
+	for i, code := range codes {
+		normalized[i] = strings.ToUpper(strings.TrimSpace(code))
+		if !IsCountryCode(normalized[i]) {
+			return nil, fmt.Errorf("%s: unknown country code %q", key, code)
+		}
+	}
+	return normalized, nil
+}
+
+func dateFactory(metadata map[string]any, _ *Rules) (Validator, error) {
+	layouts, format, err := metadataLayouts(metadata)
+	return DateValidator{Layouts: layouts, Format: format}, err
+}
+
+func timeFactory(metadata map[string]any, _ *Rules) (Validator, error) {
+	layouts, format, err := metadataLayouts(metadata)
+	return TimeValidator{Layouts: layouts, Format: format}, err
+}
+
+func dateTimeFactory(metadata map[string]any, _ *Rules) (Validator, error) {
+
+	layouts, format, err := metadataLayouts(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := DateTimeValidator{Layouts: layouts, Format: format}
+
+	timezone, ok, err := MetadataString(metadata, MetadataTimezone)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid IANA timezone: %q", MetadataTimezone, timezone)
+		}
+		validator.Location = location
+	}
+
+	return validator, nil
+}
+
+func phoneFactory(metadata map[string]any, _ *Rules) (Validator, error) {
+
+	region, ok, err := MetadataString(metadata, MetadataDefaultRegion)
+	if err != nil || !ok {
+		return PhoneValidator{}, err
+	}
+
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if !IsCountryCode(region) {
+		return nil, fmt.Errorf("%s: unknown country code %q", MetadataDefaultRegion, region)
+	}
+
+	return PhoneValidator{DefaultRegion: region}, nil
+}
+
+func urlFactory(metadata map[string]any, _ *Rules) (Validator, error) {
+
+	schemes, _, err := MetadataStrings(metadata, MetadataSchemes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Se normaliza una copia: la lista puede ser la de la metadata.
+	schemes = slices.Clone(schemes)
+	for i, scheme := range schemes {
+		schemes[i] = strings.ToLower(strings.TrimSpace(scheme))
+		if schemes[i] == "" {
+			return nil, errors.New(MetadataSchemes + " must not contain empty values")
+		}
+	}
+
+	return URLValidator{Schemes: schemes}, nil
+}
+
+func countryFactory(metadata map[string]any, _ *Rules) (Validator, error) {
+	countries, err := metadataCountryCodes(metadata, MetadataCountries)
+	return CountryValidator{Countries: countries}, err
+}
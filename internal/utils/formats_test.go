@@ -0,0 +1,70 @@
+package utils
+
+import "testing"
+
+func TestTimeValidatorFormat(t *testing.T) {
+
+	tests := []struct {
+		format string
+		value  string
+		want   string
+	}{
+		{format: "HH:mm", value: "9:05", want: "09:05:00"},
+		{format: "HH:mm", value: "09:05", want: "09:05:00"},
+		{format: "hh:mm A", value: "09:05 PM", want: "21:05:00"},
+		// La zona se valida pero no se conserva ni se convierte.
+		{format: "HH:mmZ", value: "09:05+02:00", want: "09:05:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.value, func(t *testing.T) {
+			layout, err := ParseLayout(tt.format)
+			if err != nil {
+				t.Fatalf("ParseLayout(%q): %v", tt.format, err)
+			}
+			got, ok := TimeValidator{Layouts: []string{layout}, Format: tt.format}.Normalize(tt.value)
+			if !ok || got != tt.want {
+				t.Errorf("Normalize(%q) = %q, %v, want %q", tt.value, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{name: "date", format: "DD/MM/YYYY", want: "02/01/2006"},
+		{name: "short tokens", format: "D-M-YY", want: "2-1-06"},
+		{name: "12 hour clock", format: "hh:mm A", want: "03:04 PM"},
+		{name: "24 hour clock with seconds", format: "HH:mm:ss", want: "15:04:05"},
+		{name: "single digit 24 hour", format: "H:mm", wantErr: true},
+		{name: "iso T", format: "YYYY-MM-DDTHH:mm", want: "2006-01-02T15:04"},
+		{name: "zone", format: "YYYY-MM-DDTHH:mm:ssZ", want: "2006-01-02T15:04:05Z07:00"},
+		{name: "bracketed literal", format: "DD [de] MM [de] YYYY", want: "02 de 01 de 2006"},
+		{name: "escaped literal", format: "HH\\hmm", want: "15h04"},
+		{name: "empty", format: " ", wantErr: true},
+		{name: "unescaped letter", format: "YYYY-MM-DD x", wantErr: true},
+		{name: "unescaped digit", format: "YYYY1", wantErr: true},
+		{name: "unclosed bracket", format: "DD [de MM", wantErr: true},
+		{name: "trailing escape", format: "YYYY\\", wantErr: true},
+		{name: "literal read as month name", format: "YYYY [Mon]", wantErr: true},
+		{name: "literal read as day", format: "MM [2]", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLayout(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLayout(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLayout(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Claves de metadata de las preguntas de tipo matrix.
+const (
+	MetadataRows    = "rows"
+	MetadataColumns = "columns"
+	// MetadataMultiple permite marcar varias columnas por fila.
+	MetadataMultiple = "multiple"
+	// MetadataAllRows obliga a responder todas las filas.
+	MetadataAllRows = "all_rows"
+)
+
+// MatrixValidator lo implementan los validadores de preguntas cuya respuesta
+// es una selección de columnas por fila.
+type MatrixValidator interface {
+	Validator
+	IsValidMatrix(selections map[string][]string) bool
+}
+
+// ValidateMatrix valida una respuesta de matriz; los validadores que no son de
+// matriz la rechazan. Las matrices no admiten reglas (ParseRules las rechaza).
+func ValidateMatrix(validator Validator, selections map[string][]string) bool {
+	matrix, ok := validator.(MatrixValidator)
+	return ok && matrix.IsValidMatrix(selections)
+}
+
+// IsMatrix indica si el tipo responde con una matriz.
+func IsMatrix(questionType QuestionType) bool {
+	return questionType == QuestionTypeMatrix
+}
+
+// Matrix (filas × columnas; una columna por fila salvo multiple)
+type GridValidator struct {
+	Rows     []string
+	Columns  []string
+	Multiple bool
+	AllRows  bool
+}
+
+func (g GridValidator) Name() string { return string(QuestionTypeMatrix) }
+
+// IsValid rechaza siempre: la respuesta debe llegar en matrix.
+func (g GridValidator) IsValid(value string) bool {
+	return false
+}
+
+func (g GridValidator) IsValidMatrix(selections map[string][]string) bool {
+
+	if len(selections) == 0 {
+		return false
+	}
+
+	for row, columns := range selections {
+
+		if !slices.Contains(g.Rows, row) || len(columns) == 0 {
+			return false
+		}
+
+		if !g.Multiple && len(columns) > 1 {
+			return false
+		}
+
+		seen := make(map[string]bool, len(columns))
+		for _, column := range columns {
+			if seen[column] || !slices.Contains(g.Columns, column) {
+				return false
+			}
+			seen[column] = true
+		}
+	}
+
+	return !g.AllRows || len(selections) == len(g.Rows)
+}
+
+func (g GridValidator) Description() string {
+
+	description := "Matrix (rows: " + strings.Join(g.Rows, ", ") + "; columns: " + strings.Join(g.Columns, ", ")
+	if g.Multiple {
+		description += "; several columns per row"
+	} else {
+		description += "; one column per row"
+	}
+	if g.AllRows {
+		description += "; all rows required"
+	}
+	return description + ")"
+}
+
+// metadataUniqueStrings lee una lista obligatoria, no vacía y sin repetidos.
+// Tampoco admite ":", que separa fila y columna en el scoring de los quizzes.
+func metadataUniqueStrings(metadata map[string]any, key string) ([]string, error) {
+
+	values, ok, err := MetadataStrings(metadata, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok || len(values) == 0 {
+		return nil, fmt.Errorf("%s is required and must not be empty", key)
+	}
+
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" || seen[value] {
+			return nil, fmt.Errorf("%s must not contain empty or repeated values", key)
+		}
+		if strings.Contains(value, ":") {
+			return nil, fmt.Errorf("%s must not contain \":\": %q", key, value)
+		}
+		seen[value] = true
+	}
+
+	return values, nil
+}
+
+func matrixFactory(metadata map[string]any, _ *Rules) (Validator, error) {
+
+	rows, err := metadataUniqueStrings(metadata, MetadataRows)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := metadataUniqueStrings(metadata, MetadataColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	multiple, _, err := MetadataBool(metadata, MetadataMultiple)
+	if err != nil {
+		return nil, err
+	}
+
+	allRows, _, err := MetadataBool(metadata, MetadataAllRows)
+	if err != nil {
+		return nil, err
+	}
+
+	return GridValidator{Rows: rows, Columns: columns, Multiple: multiple, AllRows: allRows}, nil
+}
@@ -0,0 +1,28 @@
+package utils
+
+import "testing"
+
+func TestMatrixValidatorMetadata(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		metadata map[string]any
+		wantErr  bool
+	}{
+		{name: "rows and columns", metadata: map[string]any{MetadataRows: []any{"Precio", "Calidad"}, MetadataColumns: []any{"Bien", "Mal"}}},
+		{name: "missing columns", metadata: map[string]any{MetadataRows: []any{"Precio"}}, wantErr: true},
+		{name: "repeated row", metadata: map[string]any{MetadataRows: []any{"Precio", "Precio"}, MetadataColumns: []any{"Bien"}}, wantErr: true},
+		{name: "colon in a row", metadata: map[string]any{MetadataRows: []any{"Hora: mañana"}, MetadataColumns: []any{"Bien"}}, wantErr: true},
+		{name: "colon in a column", metadata: map[string]any{MetadataRows: []any{"Precio"}, MetadataColumns: []any{"1:1"}}, wantErr: true},
+		{name: "rules", metadata: map[string]any{MetadataRows: []any{"Precio"}, MetadataColumns: []any{"Bien"}, RuleMinLength: 2}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewValidator(QuestionTypeMatrix, tt.metadata)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewValidator(matrix, %v) error = %v, wantErr %v", tt.metadata, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Helpers para leer la metadata de una pregunta. La metadata llega como JSON
+// (números float64, arreglos []any) o desde Mongo (int32/int64, primitive.A),
+// por lo que cada helper acepta ambas representaciones. El bool indica si la
+// clave estaba presente.
+
+func MetadataFloat(metadata map[string]any, key string) (float64, bool, error) {
+
+	raw, ok := metadata[key]
+	if !ok || raw == nil {
+		return 0, false, nil
+	}
+
+	switch v := raw.(type) {
+	case float64:
+		return v, true, nil
+	case float32:
+		return float64(v), true, nil
+	case int:
+		return float64(v), true, nil
+	case int32:
+		return float64(v), true, nil
+	case int64:
+		return float64(v), true, nil
+	default:
+		return 0, true, fmt.Errorf("%s must be a number", key)
+	}
+}
+
+func MetadataInt(metadata map[string]any, key string) (int, bool, error) {
+
+	value, ok, err := MetadataFloat(metadata, key)
+	if !ok || err != nil {
+		return 0, ok, err
+	}
+
+	if value != math.Trunc(value) {
+		return 0, true, fmt.Errorf("%s must be an integer", key)
+	}
+
+	return int(value), true, nil
+}
+
+func MetadataString(metadata map[string]any, key string) (string, bool, error) {
+
+	raw, ok := metadata[key]
+	if !ok || raw == nil {
+		return "", false, nil
+	}
+
+	value, isString := raw.(string)
+	if !isString {
+		return "", true, fmt.Errorf("%s must be a string", key)
+	}
+
+	return value, true, nil
+}
+
+func MetadataBool(metadata map[string]any, key string) (bool, bool, error) {
+
+	raw, ok := metadata[key]
+	if !ok || raw == nil {
+		return false, false, nil
+	}
+
+	value, isBool := raw.(bool)
+	if !isBool {
+		return false, true, fmt.Errorf("%s must be a boolean", key)
+	}
+
+	return value, true, nil
+}
+
+func MetadataStrings(metadata map[string]any, key string) ([]string, bool, error) {
+
+	raw, ok := metadata[key]
+	if !ok || raw == nil {
+		return nil, false, nil
+	}
+
+	var items []any
+	switch v := raw.(type) {
+	case []string:
+		return v, true, nil
+	case []any:
+		items = v
+	case primitive.A:
+		items = v
+	default:
+		return nil, true, fmt.Errorf("%s must be a list of strings", key)
+	}
+
+	values := make([]string, len(items))
+	for i, item := range items {
+		value, isString := item.(string)
+		if !isString {
+			return nil, true, fmt.Errorf("%s must be a list of strings", key)
+		}
+		values[i] = value
+	}
+
+	return values, true, nil
+}
+
+// MetadataStringMap lee un objeto cuyos valores son strings. Desde Mongo los
+// objetos anidados llegan como primitive.D o primitive.M.
+func MetadataStringMap(metadata map[string]any, key string) (map[string]string, bool, error) {
+
+	raw, ok := metadata[key]
+	if !ok || raw == nil {
+		return nil, false, nil
+	}
+
+	var items map[string]any
+	switch v := raw.(type) {
+	case map[string]string:
+		return v, true, nil
+	case map[string]any:
+		items = v
+	case primitive.M:
+		items = v
+	case primitive.D:
+		items = v.Map()
+	default:
+		return nil, true, fmt.Errorf("%s must be an object of strings", key)
+	}
+
+	values := make(map[string]string, len(items))
+	for name, item := range items {
+		value, isString := item.(string)
+		if !isString {
+			return nil, true, fmt.Errorf("%s must be an object of strings", key)
+		}
+		values[name] = value
+	}
+
+	return values, true, nil
+}
+
+// MetadataDate lee una fecha en formato YYYY-MM-DD.
+func MetadataDate(metadata map[string]any, key string) (time.Time, bool, error) {
+
+	value, ok, err := MetadataString(metadata, key)
+	if !ok || err != nil {
+		return time.Time{}, ok, err
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, true, fmt.Errorf("%s must be a date (YYYY-MM-DD)", key)
+	}
+
+	return date, true, nil
+}
@@ -0,0 +1,278 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Claves de metadata de las preguntas numéricas y de escala.
+const (
+	// MetadataStep es el incremento entre valores válidos, contado desde min (o 0).
+	MetadataStep = "step"
+	// MetadataLabels etiqueta cada punto de una escala, en orden.
+	MetadataLabels = "labels"
+	// MetadataMinLabel y MetadataMaxLabel etiquetan los extremos de una escala.
+	MetadataMinLabel = "min_label"
+	MetadataMaxLabel = "max_label"
+)
+
+const (
+	DefaultRatingMax = 5
+	DefaultScaleMin  = 1
+	DefaultScaleMax  = 5
+	NPSMin           = 0
+	NPSMax           = 10
+
+	// MaxScalePoints limita el número de puntos de rating y escalas.
+	MaxScalePoints = 101
+
+	// stepTolerance absorbe los errores de redondeo al comprobar el step.
+	stepTolerance = 1e-9
+)
+
+// parseNumber acepta números finitos con punto decimal.
+func parseNumber(value string) (float64, bool) {
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, false
+	}
+	return number, true
+}
+
+// onStep indica si number está a un múltiplo exacto de step desde base.
+func onStep(number float64, base float64, step float64) bool {
+
+	if step <= 0 {
+		return true
+	}
+
+	steps := (number - base) / step
+	return math.Abs(steps-math.Round(steps)) < stepTolerance
+}
+
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// Number / integer (límites con min/max y step opcional)
+type NumberValidator struct {
+	Min     *float64
+	Max     *float64
+	Step    float64
+	Integer bool
+}
+
+func (n NumberValidator) Name() string {
+	if n.Integer {
+		return string(QuestionTypeInteger)
+	}
+	return string(QuestionTypeNumber)
+}
+
+func (n NumberValidator) IsValid(value string) bool {
+
+	number, ok := parseNumber(value)
+	if !ok {
+		return false
+	}
+
+	if n.Integer && number != math.Trunc(number) {
+		return false
+	}
+
+	if n.Min != nil && number < *n.Min {
+		return false
+	}
+
+	if n.Max != nil && number > *n.Max {
+		return false
+	}
+
+	base := 0.0
+	if n.Min != nil {
+		base = *n.Min
+	}
+
+	return onStep(number, base, n.Step)
+}
+
+func (n NumberValidator) Description() string {
+
+	parts := []string{"Number"}
+	if n.Integer {
+		parts = []string{"Integer"}
+	}
+
+	if n.Min != nil {
+		parts = append(parts, ">= "+formatNumber(*n.Min))
+	}
+	if n.Max != nil {
+		parts = append(parts, "<= "+formatNumber(*n.Max))
+	}
+	if n.Step > 0 {
+		parts = append(parts, "step "+formatNumber(n.Step))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Scale (rating, escala lineal y NPS: un entero entre Min y Max, de Step en Step)
+type ScaleValidator struct {
+	Type   QuestionType
+	Min    int
+	Max    int
+	Step   int
+	Labels []string
+}
+
+func (s ScaleValidator) step() int {
+	if s.Step > 0 {
+		return s.Step
+	}
+	return 1
+}
+
+// Points devuelve el número de valores posibles de la escala.
+func (s ScaleValidator) Points() int {
+	return (s.Max-s.Min)/s.step() + 1
+}
+
+func (s ScaleValidator) Name() string { return string(s.Type) }
+func (s ScaleValidator) IsValid(value string) bool {
+
+	number, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+
+	return number >= s.Min && number <= s.Max && (number-s.Min)%s.step() == 0
+}
+
+func (s ScaleValidator) Description() string {
+
+	name := map[QuestionType]string{QuestionTypeRating: "Rating", QuestionTypeNPS: "NPS"}[s.Type]
+	if name == "" {
+		name = "Scale"
+	}
+
+	description := fmt.Sprintf("%s (integer from %d to %d", name, s.Min, s.Max)
+	if s.step() > 1 {
+		description += fmt.Sprintf(", step %d", s.step())
+	}
+	return description + ")"
+}
+
+// numberFactory consume min/max de las reglas y lee el step.
+func numberFactory(integer bool) ValidatorFactory {
+	return func(metadata map[string]any, rules *Rules) (Validator, error) {
+
+		validator := NumberValidator{Min: rules.Min, Max: rules.Max, Integer: integer}
+		rules.Min, rules.Max = nil, nil
+
+		step, ok, err := MetadataFloat(metadata, MetadataStep)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if step <= 0 {
+				return nil, errors.New(MetadataStep + " must be greater than 0")
+			}
+			if integer && step != math.Trunc(step) {
+				return nil, errors.New(MetadataStep + " must be an integer")
+			}
+			validator.Step = step
+		}
+
+		if integer {
+			if validator.Min != nil && *validator.Min != math.Trunc(*validator.Min) {
+				return nil, errors.New(RuleMin + " must be an integer")
+			}
+			if validator.Max != nil && *validator.Max != math.Trunc(*validator.Max) {
+				return nil, errors.New(RuleMax + " must be an integer")
+			}
+		}
+
+		return validator, nil
+	}
+}
+
+// scaleBound lee un extremo entero de la escala, consumiendo la regla.
+func scaleBound(rule **float64, key string, fallback int) (int, error) {
+
+	if *rule == nil {
+		return fallback, nil
+	}
+
+	value := **rule
+	*rule = nil
+
+	if value != math.Trunc(value) {
+		return 0, errors.New(key + " must be an integer")
+	}
+	return int(value), nil
+}
+
+// scaleFactory construye rating, escala lineal y NPS. Las escalas con extremos
+// fijos (NPS) ignoran min y max de la metadata.
+func scaleFactory(questionType QuestionType, defaultMin int, defaultMax int, fixed bool) ValidatorFactory {
+	return func(metadata map[string]any, rules *Rules) (Validator, error) {
+
+		validator := ScaleValidator{Type: questionType, Min: defaultMin, Max: defaultMax}
+
+		var err error
+		if fixed {
+			rules.Min, rules.Max = nil, nil
+		} else {
+			if validator.Min, err = scaleBound(&rules.Min, RuleMin, defaultMin); err != nil {
+				return nil, err
+			}
+			if validator.Max, err = scaleBound(&rules.Max, RuleMax, defaultMax); err != nil {
+				return nil, err
+			}
+		}
+
+		step, ok, err := MetadataInt(metadata, MetadataStep)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if step <= 0 {
+				return nil, errors.New(MetadataStep + " must be greater than 0")
+			}
+			validator.Step = step
+		}
+
+		if validator.Min >= validator.Max {
+			return nil, errors.New(RuleMin + " must be less than " + RuleMax)
+		}
+
+		if (validator.Max-validator.Min)%validator.step() != 0 {
+			return nil, fmt.Errorf("%s - %s must be a multiple of %s", RuleMax, RuleMin, MetadataStep)
+		}
+
+		if validator.Points() > MaxScalePoints {
+			return nil, fmt.Errorf("scale must have at most %d points", MaxScalePoints)
+		}
+
+		labels, ok, err := MetadataStrings(metadata, MetadataLabels)
+		if err != nil {
+			return nil, err
+		}
+		if ok && len(labels) != validator.Points() {
+			return nil, fmt.Errorf("%s must have one label per point (%d)", MetadataLabels, validator.Points())
+		}
+		validator.Labels = labels
+
+		for _, key := range []string{MetadataMinLabel, MetadataMaxLabel} {
+			if _, _, err := MetadataString(metadata, key); err != nil {
+				return nil, err
+			}
+		}
+
+		return validator, nil
+	}
+}
@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Claves de metadata de las preguntas con opciones.
+const (
+	// MetadataOptions son las opciones de radio, select, checkbox y dropdowns.
+	MetadataOptions = "options"
+	// MetadataMinSelections y MetadataMaxSelections limitan cuántas opciones
+	// se pueden marcar en checkbox y multi-dropdown.
+	MetadataMinSelections = "min_selections"
+	MetadataMaxSelections = "max_selections"
+)
+
+// ValidatorFactory construye el validador de un tipo de pregunta a partir de su
+// metadata. Las reglas que el validador aplique por sí mismo (p. ej. min_length
+// en text-long) deben quitarse de rules para no evaluarlas dos veces.
+type ValidatorFactory func(metadata map[string]any, rules *Rules) (Validator, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[QuestionType]ValidatorFactory{}
+)
+
+// RegisterValidator registra (o reemplaza) la fábrica de validadores de un tipo
+// de pregunta. Permite añadir tipos propios sin modificar el servicio; debe
+// llamarse durante el arranque, antes de atender peticiones.
+func RegisterValidator(questionType QuestionType, factory ValidatorFactory) {
+
+	if questionType == "" || factory == nil {
+		panic("utils: RegisterValidator requires a question type and a factory")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[questionType] = factory
+}
+
+// IsRegisteredType indica si existe un validador para el tipo de pregunta.
+func IsRegisteredType(questionType QuestionType) bool {
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	_, ok := registry[questionType]
+	return ok
+}
+
+// NewValidator construye el validador de una pregunta: el del tipo, configurado
+// con su metadata, más las reglas declaradas que el tipo no haya consumido.
+func NewValidator(questionType QuestionType, metadata map[string]any) (Validator, error) {
+
+	registryMu.RLock()
+	factory, ok := registry[questionType]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, errors.New("invalid question type: " + string(questionType))
+	}
+
+	rules, err := ParseRules(questionType, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	validator, err := factory(metadata, &rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return WithRules(validator, rules), nil
+}
+
+// StaticValidator registra un validador que no depende de la metadata.
+func StaticValidator(validator Validator) ValidatorFactory {
+	return func(map[string]any, *Rules) (Validator, error) {
+		return validator, nil
+	}
+}
+
+// metadataOptions lee las opciones obligatorias de la metadata.
+func metadataOptions(metadata map[string]any) ([]string, error) {
+
+	options, ok, err := MetadataStrings(metadata, MetadataOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok || len(options) == 0 {
+		return nil, fmt.Errorf("%s is required and must not be empty", MetadataOptions)
+	}
+
+	return options, nil
+}
+
+// metadataSelections lee los límites de selección y comprueba que sean
+// alcanzables con las opciones disponibles.
+func metadataSelections(metadata map[string]any, options []string) (Selections, error) {
+
+	var selections Selections
+
+	minSelections, _, err := MetadataInt(metadata, MetadataMinSelections)
+	if err != nil {
+		return selections, err
+	}
+
+	maxSelections, _, err := MetadataInt(metadata, MetadataMaxSelections)
+	if err != nil {
+		return selections, err
+	}
+
+	if minSelections < 0 || maxSelections < 0 {
+		return selections, fmt.Errorf("%s and %s must be greater or equal than 0", MetadataMinSelections, MetadataMaxSelections)
+	}
+
+	if maxSelections > 0 && minSelections > maxSelections {
+		return selections, fmt.Errorf("%s must be less or equal than %s", MetadataMinSelections, MetadataMaxSelections)
+	}
+
+	if minSelections > len(options) {
+		return selections, fmt.Errorf("%s must be less or equal than the number of options", MetadataMinSelections)
+	}
+
+	selections.Min = minSelections
+	selections.Max = maxSelections
+
+	return selections, nil
+}
+
+// multiOptions lee las opciones y los límites de selección de un tipo de varios valores.
+func multiOptions(metadata map[string]any) ([]string, Selections, error) {
+
+	options, err := metadataOptions(metadata)
+	if err != nil {
+		return nil, Selections{}, err
+	}
+
+	selections, err := metadataSelections(metadata, options)
+	return options, selections, err
+}
+
+func init() {
+
+	RegisterValidator(QuestionTypeText, StaticValidator(TextValidator{}))
+	RegisterValidator(QuestionTypeTextEmail, StaticValidator(EmailValidator{}))
+	RegisterValidator(QuestionTypeBoolean, StaticValidator(BooleanValidator{}))
+	RegisterValidator(QuestionTypeDate, dateFactory)
+	RegisterValidator(QuestionTypeTime, timeFactory)
+	RegisterValidator(QuestionTypeDateTime, dateTimeFactory)
+	RegisterValidator(QuestionTypePhone, phoneFactory)
+	RegisterValidator(QuestionTypeURL, urlFactory)
+	RegisterValidator(QuestionTypeCountry, countryFactory)
+	RegisterValidator(QuestionTypeHidden, hiddenFactory)
+	RegisterValidator(QuestionTypeComputed, computedFactory)
+
+	RegisterValidator(QuestionTypeNumber, numberFactory(false))
+	RegisterValidator(QuestionTypeInteger, numberFactory(true))
+	RegisterValidator(QuestionTypeRating, scaleFactory(QuestionTypeRating, 1, DefaultRatingMax, false))
+	RegisterValidator(QuestionTypeScale, scaleFactory(QuestionTypeScale, DefaultScaleMin, DefaultScaleMax, false))
+	RegisterValidator(QuestionTypeNPS, scaleFactory(QuestionTypeNPS, NPSMin, NPSMax, true))
+	RegisterValidator(QuestionTypeMatrix, matrixFactory)
+
+	RegisterValidator(QuestionTypeTextLong, func(_ map[string]any, rules *Rules) (Validator, error) {
+		validator := TextLongValidator{MinLength: rules.MinLength}
+		rules.MinLength = nil
+		return validator, nil
+	})
+
+	RegisterValidator(QuestionTypeTextShort, func(_ map[string]any, rules *Rules) (Validator, error) {
+		validator := TextShortValidator{}
+		if rules.MaxLength != nil {
+			validator.MaxLength = *rules.MaxLength
+			rules.MaxLength = nil
+		}
+		return validator, nil
+	})
+
+	RegisterValidator(QuestionTypeFile, func(metadata map[string]any, rules *Rules) (Validator, error) {
+		// Los límites de contenido se aplican al subir el archivo; aquí solo
+		// se comprueba que estén bien configurados.
+		if _, err := ParseFileLimits(metadata); err != nil {
+			return nil, err
+		}
+		validator := FileValidator{AllowedExtensions: rules.AllowedExtensions}
+		rules.AllowedExtensions = nil
+		return validator, nil
+	})
+
+	RegisterValidator(QuestionTypeRadio, func(metadata map[string]any, _ *Rules) (Validator, error) {
+		options, err := metadataOptions(metadata)
+		return RadioValidator{Options: options}, err
+	})
+
+	RegisterValidator(QuestionTypeSelect, func(metadata map[string]any, _ *Rules) (Validator, error) {
+		options, err := metadataOptions(metadata)
+		return SelectValidator{Options: options}, err
+	})
+
+	RegisterValidator(QuestionTypeCheckbox, func(metadata map[string]any, _ *Rules) (Validator, error) {
+		options, selections, err := multiOptions(metadata)
+		return CheckboxValidator{Options: options, Selections: selections}, err
+	})
+
+	RegisterValidator(QuestionTypeMultiDropdown, func(metadata map[string]any, _ *Rules) (Validator, error) {
+		options, selections, err := multiOptions(metadata)
+		return MultiDropdownValidator{Options: options, Selections: selections}, err
+	})
+
+	RegisterValidator(QuestionTypeDropdown, func(metadata map[string]any, _ *Rules) (Validator, error) {
+		// Los dropdowns creados antes de que llevaran opciones en la metadata
+		// siguen aceptando la lista fija de entonces.
+		if _, ok := metadata[MetadataOptions]; !ok {
+			return DropdownValidator{Options: LegacyDropdownOptions}, nil
+		}
+		options, err := metadataOptions(metadata)
+		return DropdownValidator{Options: options}, err
+	})
+}
@@ -0,0 +1,332 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Claves de metadata con las que el autor del formulario declara reglas de validación.
+const (
+	RuleMinLength         = "min_length"
+	RuleMaxLength         = "max_length"
+	RulePattern           = "pattern"
+	RuleMin               = "min"
+	RuleMax               = "max"
+	RuleAllowedExtensions = "allowed_extensions"
+	RuleMinDate           = "min_date"
+	RuleMaxDate           = "max_date"
+)
+
+// textRules son las reglas de los tipos de texto libre.
+var textRules = []string{RuleMinLength, RuleMaxLength, RulePattern}
+
+// typeRules son las reglas que admite cada tipo propio del servicio. Los tipos
+// registrados desde fuera (sin entrada) admiten cualquier regla.
+var typeRules = map[QuestionType][]string{
+	QuestionTypeText:      textRules,
+	QuestionTypeTextLong:  textRules,
+	QuestionTypeTextShort: textRules,
+	QuestionTypeTextEmail: textRules,
+	QuestionTypePhone:     textRules,
+	QuestionTypeURL:       textRules,
+
+	QuestionTypeNumber:  {RuleMin, RuleMax},
+	QuestionTypeInteger: {RuleMin, RuleMax},
+	QuestionTypeRating:  {RuleMin, RuleMax},
+	QuestionTypeScale:   {RuleMin, RuleMax},
+
+	QuestionTypeDate: {RuleMinDate, RuleMaxDate},
+	QuestionTypeFile: {RuleAllowedExtensions},
+
+	QuestionTypeRadio:         {},
+	QuestionTypeSelect:        {},
+	QuestionTypeCheckbox:      {},
+	QuestionTypeDropdown:      {},
+	QuestionTypeMultiDropdown: {},
+	QuestionTypeBoolean:       {},
+	QuestionTypeTime:          {},
+	QuestionTypeDateTime:      {},
+	QuestionTypeCountry:       {},
+	QuestionTypeNPS:           {},
+	QuestionTypeMatrix:        {},
+	QuestionTypeHidden:        {},
+	QuestionTypeComputed:      {},
+}
+
+// RuleKeys son todas las claves de reglas, en el orden en que se comprueban.
+var RuleKeys = []string{RuleMinLength, RuleMaxLength, RulePattern, RuleMin, RuleMax, RuleAllowedExtensions, RuleMinDate, RuleMaxDate}
+
+// AppliesRule indica si el tipo de pregunta admite la regla.
+func AppliesRule(questionType QuestionType, rule string) bool {
+	allowed, ok := typeRules[questionType]
+	return !ok || slices.Contains(allowed, rule)
+}
+
+// Rules son las reglas declaradas en la metadata de una pregunta.
+type Rules struct {
+	MinLength         *int
+	MaxLength         *int
+	Pattern           *regexp.Regexp
+	Min               *float64
+	Max               *float64
+	AllowedExtensions []string
+	MinDate           *time.Time
+	MaxDate           *time.Time
+}
+
+// ParseRules lee y valida las reglas de la metadata. Devuelve error si alguna
+// regla no se aplica al tipo de pregunta (p. ej. min_date en boolean), tiene
+// un tipo incorrecto o es incoherente (p. ej. min mayor que max).
+func ParseRules(questionType QuestionType, metadata map[string]any) (Rules, error) {
+
+	var rules Rules
+
+	for _, rule := range RuleKeys {
+		if _, declared := metadata[rule]; declared && !AppliesRule(questionType, rule) {
+			return rules, fmt.Errorf("%s does not apply to %s questions", rule, questionType)
+		}
+	}
+
+	minLength, ok, err := MetadataInt(metadata, RuleMinLength)
+	if err != nil {
+		return rules, err
+	}
+	if ok {
+		if minLength < 0 {
+			return rules, errors.New(RuleMinLength + " must be greater or equal than 0")
+		}
+		rules.MinLength = &minLength
+	}
+
+	maxLength, ok, err := MetadataInt(metadata, RuleMaxLength)
+	if err != nil {
+		return rules, err
+	}
+	if ok {
+		if maxLength < 1 {
+			return rules, errors.New(RuleMaxLength + " must be greater than 0")
+		}
+		rules.MaxLength = &maxLength
+	}
+
+	if rules.MinLength != nil && rules.MaxLength != nil && *rules.MinLength > *rules.MaxLength {
+		return rules, errors.New(RuleMinLength + " must be less or equal than " + RuleMaxLength)
+	}
+
+	pattern, ok, err := MetadataString(metadata, RulePattern)
+	if err != nil {
+		return rules, err
+	}
+	if ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return rules, fmt.Errorf("%s is not a valid regular expression: %w", RulePattern, err)
+		}
+		rules.Pattern = re
+	}
+
+	min, ok, err := MetadataFloat(metadata, RuleMin)
+	if err != nil {
+		return rules, err
+	}
+	if ok {
+		rules.Min = &min
+	}
+
+	max, ok, err := MetadataFloat(metadata, RuleMax)
+	if err != nil {
+		return rules, err
+	}
+	if ok {
+		rules.Max = &max
+	}
+
+	if rules.Min != nil && rules.Max != nil && *rules.Min > *rules.Max {
+		return rules, errors.New(RuleMin + " must be less or equal than " + RuleMax)
+	}
+
+	extensions, ok, err := MetadataStrings(metadata, RuleAllowedExtensions)
+	if err != nil {
+		return rules, err
+	}
+	if ok {
+		if len(extensions) == 0 {
+			return rules, errors.New(RuleAllowedExtensions + " must not be empty")
+		}
+		// Se normaliza una copia: la lista puede ser la de la metadata.
+		extensions = slices.Clone(extensions)
+		for i, extension := range extensions {
+			extension = strings.ToLower(strings.TrimSpace(extension))
+			if !strings.HasPrefix(extension, ".") || len(extension) < 2 {
+				return rules, fmt.Errorf("%s must start with a dot: %q", RuleAllowedExtensions, extensions[i])
+			}
+			extensions[i] = extension
+		}
+		rules.AllowedExtensions = extensions
+	}
+
+	minDate, ok, err := MetadataDate(metadata, RuleMinDate)
+	if err != nil {
+		return rules, err
+	}
+	if ok {
+		rules.MinDate = &minDate
+	}
+
+	maxDate, ok, err := MetadataDate(metadata, RuleMaxDate)
+	if err != nil {
+		return rules, err
+	}
+	if ok {
+		rules.MaxDate = &maxDate
+	}
+
+	if rules.MinDate != nil && rules.MaxDate != nil && rules.MinDate.After(*rules.MaxDate) {
+		return rules, errors.New(RuleMinDate + " must be before " + RuleMaxDate)
+	}
+
+	return rules, nil
+}
+
+// IsValid comprueba el valor contra todas las reglas declaradas.
+func (r Rules) IsValid(value string) bool {
+
+	length := utf8.RuneCountInString(strings.TrimSpace(value))
+
+	if r.MinLength != nil && length < *r.MinLength {
+		return false
+	}
+
+	if r.MaxLength != nil && length > *r.MaxLength {
+		return false
+	}
+
+	if r.Pattern != nil && !r.Pattern.MatchString(value) {
+		return false
+	}
+
+	if r.Min != nil || r.Max != nil {
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return false
+		}
+		if r.Min != nil && number < *r.Min {
+			return false
+		}
+		if r.Max != nil && number > *r.Max {
+			return false
+		}
+	}
+
+	if len(r.AllowedExtensions) > 0 && !slices.Contains(r.AllowedExtensions, strings.ToLower(filepath.Ext(value))) {
+		return false
+	}
+
+	if r.MinDate != nil || r.MaxDate != nil {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return false
+		}
+		if r.MinDate != nil && date.Before(*r.MinDate) {
+			return false
+		}
+		if r.MaxDate != nil && date.After(*r.MaxDate) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Description describe las reglas para los mensajes de error.
+func (r Rules) Description() string {
+
+	var parts []string
+
+	if r.MinLength != nil {
+		parts = append(parts, fmt.Sprintf("at least %d chars", *r.MinLength))
+	}
+	if r.MaxLength != nil {
+		parts = append(parts, fmt.Sprintf("at most %d chars", *r.MaxLength))
+	}
+	if r.Pattern != nil {
+		parts = append(parts, "matching "+r.Pattern.String())
+	}
+	if r.Min != nil {
+		parts = append(parts, "min "+strconv.FormatFloat(*r.Min, 'f', -1, 64))
+	}
+	if r.Max != nil {
+		parts = append(parts, "max "+strconv.FormatFloat(*r.Max, 'f', -1, 64))
+	}
+	if len(r.AllowedExtensions) > 0 {
+		parts = append(parts, "extension "+strings.Join(r.AllowedExtensions, ", "))
+	}
+	if r.MinDate != nil {
+		parts = append(parts, "from "+r.MinDate.Format("2006-01-02"))
+	}
+	if r.MaxDate != nil {
+		parts = append(parts, "until "+r.MaxDate.Format("2006-01-02"))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// IsEmpty indica si no se declaró ninguna regla.
+func (r Rules) IsEmpty() bool {
+	return r.Description() == ""
+}
+
+// RulesValidator aplica las reglas declaradas además del validador del tipo.
+type RulesValidator struct {
+	Validator Validator
+	Rules     Rules
+}
+
+func (v RulesValidator) Name() string { return v.Validator.Name() }
+
+// IsValid aplica las reglas sobre el valor normalizado, de modo que min_date o
+// min comparan fechas y números en su forma canónica.
+func (v RulesValidator) IsValid(value string) bool {
+	return v.Validator.IsValid(value) && v.Rules.IsValid(Normalize(v.Validator, value))
+}
+
+func (v RulesValidator) Normalize(value string) (string, bool) {
+	if normalizer, ok := v.Validator.(Normalizer); ok {
+		return normalizer.Normalize(value)
+	}
+	return value, true
+}
+
+// IsValidValues aplica el validador del tipo a la lista y las reglas a cada valor.
+func (v RulesValidator) IsValidValues(values []string) bool {
+
+	if !ValidateValues(v.Validator, values) {
+		return false
+	}
+
+	for _, value := range values {
+		if !v.Rules.IsValid(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v RulesValidator) Description() string {
+	return v.Validator.Description() + " (" + v.Rules.Description() + ")"
+}
+
+// WithRules envuelve el validador con las reglas si hay alguna declarada.
+func WithRules(validator Validator, rules Rules) Validator {
+	if rules.IsEmpty() {
+		return validator
+	}
+	return RulesValidator{Validator: validator, Rules: rules}
+}
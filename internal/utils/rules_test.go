@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseRulesByType(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		questionType QuestionType
+		metadata     map[string]any
+		wantErr      bool
+	}{
+		{name: "length on text", questionType: QuestionTypeTextShort, metadata: map[string]any{RuleMinLength: 2, RuleMaxLength: 10}},
+		{name: "pattern on url", questionType: QuestionTypeURL, metadata: map[string]any{RulePattern: `^https://`}},
+		{name: "min on number", questionType: QuestionTypeNumber, metadata: map[string]any{RuleMin: 1}},
+		{name: "date range on date", questionType: QuestionTypeDate, metadata: map[string]any{RuleMinDate: "2025-01-01"}},
+		{name: "extensions on file", questionType: QuestionTypeFile, metadata: map[string]any{RuleAllowedExtensions: []any{".pdf"}}},
+		{name: "any rule on a custom type", questionType: "custom", metadata: map[string]any{RuleMin: 1, RulePattern: "x"}},
+		{name: "min_date on boolean", questionType: QuestionTypeBoolean, metadata: map[string]any{RuleMinDate: "2025-01-01"}, wantErr: true},
+		{name: "min on text", questionType: QuestionTypeText, metadata: map[string]any{RuleMin: 1}, wantErr: true},
+		{name: "pattern on radio", questionType: QuestionTypeRadio, metadata: map[string]any{RulePattern: "x"}, wantErr: true},
+		{name: "extensions on text", questionType: QuestionTypeTextLong, metadata: map[string]any{RuleAllowedExtensions: []any{".pdf"}}, wantErr: true},
+		{name: "min_date on datetime", questionType: QuestionTypeDateTime, metadata: map[string]any{RuleMinDate: "2025-01-01"}, wantErr: true},
+		{name: "min on nps", questionType: QuestionTypeNPS, metadata: map[string]any{RuleMin: 1}, wantErr: true},
+		{name: "pattern on matrix", questionType: QuestionTypeMatrix, metadata: map[string]any{RulePattern: "x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules(tt.questionType, tt.metadata)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRules(%s, %v) error = %v, wantErr %v", tt.questionType, tt.metadata, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRulesKeepsMetadata(t *testing.T) {
+
+	extensions := []string{" .PDF ", ".Png"}
+
+	rules, err := ParseRules(QuestionTypeFile, map[string]any{RuleAllowedExtensions: extensions})
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+
+	if !slices.Equal(rules.AllowedExtensions, []string{".pdf", ".png"}) {
+		t.Errorf("AllowedExtensions = %q, want [.pdf .png]", rules.AllowedExtensions)
+	}
+
+	if !slices.Equal(extensions, []string{" .PDF ", ".Png"}) {
+		t.Errorf("metadata was modified: %q", extensions)
+	}
+}
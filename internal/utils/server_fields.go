@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Claves de metadata de los campos que rellena el servidor.
+const (
+	// MetadataSource es el origen de un campo hidden: query o context.
+	MetadataSource = "source"
+	// MetadataParam es el nombre del parámetro o clave de contexto.
+	MetadataParam = "param"
+	// MetadataDefault es el valor de un campo hidden cuando no llega.
+	MetadataDefault = "default"
+	// MetadataExpression es la expresión de un campo computed.
+	MetadataExpression = "expression"
+	// MetadataVariables asocia los nombres usados en la expresión con question_id.
+	MetadataVariables = "variables"
+)
+
+const (
+	HiddenSourceQuery   = "query"
+	HiddenSourceContext = "context"
+)
+
+// ServerSideTypes son los tipos cuyo valor lo calcula el servidor; el cliente
+// no puede enviarlos.
+var ServerSideTypes = []QuestionType{
+	QuestionTypeHidden,
+	QuestionTypeComputed,
+}
+
+// IsServerSide indica si el valor del tipo lo rellena el servidor.
+func IsServerSide(questionType QuestionType) bool {
+	return slices.Contains(ServerSideTypes, questionType)
+}
+
+// HiddenField describe de dónde sale el valor de un campo hidden.
+type HiddenField struct {
+	Source  string
+	Param   string
+	Default string
+}
+
+func ParseHiddenField(metadata map[string]any) (HiddenField, error) {
+
+	field := HiddenField{Source: HiddenSourceQuery}
+
+	source, ok, err := MetadataString(metadata, MetadataSource)
+	if err != nil {
+		return field, err
+	}
+	if ok {
+		if source != HiddenSourceQuery && source != HiddenSourceContext {
+			return field, fmt.Errorf("%s must be %s or %s", MetadataSource, HiddenSourceQuery, HiddenSourceContext)
+		}
+		field.Source = source
+	}
+
+	param, _, err := MetadataString(metadata, MetadataParam)
+	if err != nil {
+		return field, err
+	}
+	if strings.TrimSpace(param) == "" {
+		return field, errors.New(MetadataParam + " is required")
+	}
+	field.Param = param
+
+	field.Default, _, err = MetadataString(metadata, MetadataDefault)
+	return field, err
+}
+
+// Value obtiene el valor del campo de los parámetros de la petición o del
+// contexto, o el valor por defecto.
+func (h HiddenField) Value(query map[string]string, context map[string]string) (string, bool) {
+
+	values := query
+	if h.Source == HiddenSourceContext {
+		values = context
+	}
+
+	if value, ok := values[h.Param]; ok && value != "" {
+		return value, true
+	}
+
+	return h.Default, h.Default != ""
+}
+
+// ComputedField es la expresión de un campo computed y las preguntas de las
+// que depende.
+type ComputedField struct {
+	Expression *Expression
+	// Variables asocia cada nombre de la expresión con un question_id.
+	Variables map[string]string
+}
+
+func ParseComputedField(metadata map[string]any) (ComputedField, error) {
+
+	var field ComputedField
+
+	source, _, err := MetadataString(metadata, MetadataExpression)
+	if err != nil {
+		return field, err
+	}
+
+	field.Expression, err = ParseExpression(source)
+	if err != nil {
+		return field, err
+	}
+
+	field.Variables, _, err = MetadataStringMap(metadata, MetadataVariables)
+	if err != nil {
+		return field, err
+	}
+
+	for _, name := range field.Expression.Identifiers() {
+		if field.Variables[name] == "" {
+			return field, fmt.Errorf("%s: variable %q is not declared in %s", MetadataExpression, name, MetadataVariables)
+		}
+	}
+
+	return field, nil
+}
+
+// QuestionIDs devuelve las preguntas de las que depende el campo.
+func (c ComputedField) QuestionIDs() []string {
+
+	ids := make([]string, 0, len(c.Variables))
+	for _, id := range c.Variables {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// ServerValidator acepta cualquier valor: los campos del servidor no se
+// validan contra la entrada del usuario.
+type ServerValidator struct {
+	Type QuestionType
+}
+
+func (s ServerValidator) Name() string { return string(s.Type) }
+func (s ServerValidator) IsValid(value string) bool {
+	return true
+}
+
+func (s ServerValidator) Description() string {
+	return "Server-side value (" + string(s.Type) + ")"
+}
+
+func hiddenFactory(metadata map[string]any, _ *Rules) (Validator, error) {
+	_, err := ParseHiddenField(metadata)
+	return ServerValidator{Type: QuestionTypeHidden}, err
+}
+
+func computedFactory(metadata map[string]any, _ *Rules) (Validator, error) {
+	_, err := ParseComputedField(metadata)
+	return ServerValidator{Type: QuestionTypeComputed}, err
+}
@@ -1,10 +1,12 @@
 package utils
 
 import (
+	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
-	"time"
+	"unicode/utf8"
 )
 
 type QuestionType string
@@ -15,13 +17,30 @@ const (
 	QuestionTypeTextShort QuestionType = "text-short"
 	QuestionTypeTextEmail QuestionType = "text-email"
 
-	QuestionTypeRadio    QuestionType = "radio"
-	QuestionTypeFile     QuestionType = "file"
-	QuestionTypeBoolean  QuestionType = "boolean"
-	QuestionTypeSelect   QuestionType = "select"
-	QuestionTypeCheckbox QuestionType = "checkbox"
-	QuestionTypeDropdown QuestionType = "dropdown"
-	QuestionTypeDate     QuestionType = "date"
+	QuestionTypeRadio         QuestionType = "radio"
+	QuestionTypeFile          QuestionType = "file"
+	QuestionTypeBoolean       QuestionType = "boolean"
+	QuestionTypeSelect        QuestionType = "select"
+	QuestionTypeCheckbox      QuestionType = "checkbox"
+	QuestionTypeDropdown      QuestionType = "dropdown"
+	QuestionTypeMultiDropdown QuestionType = "multi-dropdown"
+	QuestionTypeDate          QuestionType = "date"
+
+	QuestionTypeNumber  QuestionType = "number"
+	QuestionTypeInteger QuestionType = "integer"
+	QuestionTypeRating  QuestionType = "rating"
+	QuestionTypeScale   QuestionType = "scale"
+	QuestionTypeNPS     QuestionType = "nps"
+	QuestionTypeMatrix  QuestionType = "matrix"
+
+	QuestionTypePhone    QuestionType = "phone"
+	QuestionTypeURL      QuestionType = "url"
+	QuestionTypeTime     QuestionType = "time"
+	QuestionTypeDateTime QuestionType = "datetime"
+	QuestionTypeCountry  QuestionType = "country"
+
+	QuestionTypeHidden   QuestionType = "hidden"
+	QuestionTypeComputed QuestionType = "computed"
 )
 
 var QuestionTypes = []QuestionType{
@@ -35,7 +54,32 @@ var QuestionTypes = []QuestionType{
 	QuestionTypeSelect,
 	QuestionTypeCheckbox,
 	QuestionTypeDropdown,
+	QuestionTypeMultiDropdown,
 	QuestionTypeDate,
+	QuestionTypeNumber,
+	QuestionTypeInteger,
+	QuestionTypeRating,
+	QuestionTypeScale,
+	QuestionTypeNPS,
+	QuestionTypeMatrix,
+	QuestionTypePhone,
+	QuestionTypeURL,
+	QuestionTypeTime,
+	QuestionTypeDateTime,
+	QuestionTypeCountry,
+	QuestionTypeHidden,
+	QuestionTypeComputed,
+}
+
+// MultiValueTypes son los tipos cuyas respuestas pueden llegar en Values.
+var MultiValueTypes = []QuestionType{
+	QuestionTypeCheckbox,
+	QuestionTypeMultiDropdown,
+}
+
+// IsMultiValue indica si el tipo acepta varios valores.
+func IsMultiValue(questionType QuestionType) bool {
+	return slices.Contains(MultiValueTypes, questionType)
 }
 
 // Interfaz genérica
@@ -45,6 +89,28 @@ type Validator interface {
 	Description() string
 }
 
+// MultiValidator lo implementan los validadores de preguntas de varios valores.
+type MultiValidator interface {
+	Validator
+	IsValidValues(values []string) bool
+}
+
+// ValidateValues valida una lista de valores: con IsValidValues si el
+// validador es de varios valores o valor a valor en otro caso.
+func ValidateValues(validator Validator, values []string) bool {
+
+	if multi, ok := validator.(MultiValidator); ok {
+		return multi.IsValidValues(values)
+	}
+
+	for _, value := range values {
+		if !validator.IsValid(value) {
+			return false
+		}
+	}
+	return true
+}
+
 // ========== Implementaciones ==========
 
 // Texto genérico (no vacío)
@@ -59,28 +125,52 @@ func (t TextValidator) Description() string {
 	return "Generic text (not empty)"
 }
 
-// Texto largo (> 20 chars por ejemplo)
-type TextLongValidator struct{}
+// Texto largo (> 20 chars por defecto, configurable con min_length; nil usa
+// el valor por defecto y 0 no exige longitud mínima)
+type TextLongValidator struct {
+	MinLength *int
+}
+
+const DefaultTextLongMinLength = 21
+
+// EffectiveMinLength devuelve la longitud mínima que se aplica.
+func (t TextLongValidator) EffectiveMinLength() int {
+	if t.MinLength != nil {
+		return *t.MinLength
+	}
+	return DefaultTextLongMinLength
+}
 
 func (t TextLongValidator) Name() string { return string(QuestionTypeTextLong) }
 func (t TextLongValidator) IsValid(value string) bool {
-	return len(strings.TrimSpace(value)) > 20
+	return utf8.RuneCountInString(strings.TrimSpace(value)) >= t.EffectiveMinLength()
 }
 
 func (t TextLongValidator) Description() string {
-	return "Long text (> 20 chars)"
+	return fmt.Sprintf("Long text (>= %d chars)", t.EffectiveMinLength())
+}
+
+// Texto corto (<= 50 chars por defecto, configurable con max_length)
+type TextShortValidator struct {
+	MaxLength int
 }
 
-// Texto corto (< 50 chars por ejemplo)
-type TextShortValidator struct{}
+const DefaultTextShortMaxLength = 50
+
+func (t TextShortValidator) maxLength() int {
+	if t.MaxLength > 0 {
+		return t.MaxLength
+	}
+	return DefaultTextShortMaxLength
+}
 
 func (t TextShortValidator) Name() string { return string(QuestionTypeTextShort) }
 func (t TextShortValidator) IsValid(value string) bool {
-	return len(strings.TrimSpace(value)) > 0 && len(value) <= 50
+	return len(strings.TrimSpace(value)) > 0 && utf8.RuneCountInString(value) <= t.maxLength()
 }
 
 func (t TextShortValidator) Description() string {
-	return "Short text (< 50 chars)"
+	return fmt.Sprintf("Short text (<= %d chars)", t.maxLength())
 }
 
 // Email
@@ -96,7 +186,7 @@ func (e EmailValidator) Description() string {
 	return "Email (valid format)"
 }
 
-// Radio (valor debe estar en un set predefinido, aquí simulamos)
+// Radio (valor debe estar en las opciones de la metadata)
 type RadioValidator struct {
 	Options []string
 }
@@ -113,17 +203,27 @@ func (r RadioValidator) IsValid(value string) bool {
 }
 
 func (r RadioValidator) Description() string {
-	return "Radio (value must be in a predefined set, here we simulate)"
+	return "Radio (value must be one of: " + strings.Join(r.Options, ", ") + ")"
+}
+
+// File (validamos extensión simple, configurable con allowed_extensions)
+type FileValidator struct {
+	AllowedExtensions []string
 }
 
-// File (validamos extensión simple)
-type FileValidator struct{}
+var DefaultFileExtensions = []string{".jpg", ".png", ".pdf", ".txt"}
+
+func (f FileValidator) allowedExtensions() []string {
+	if len(f.AllowedExtensions) > 0 {
+		return f.AllowedExtensions
+	}
+	return DefaultFileExtensions
+}
 
 func (f FileValidator) Name() string { return string(QuestionTypeFile) }
 func (f FileValidator) IsValid(value string) bool {
 	ext := strings.ToLower(filepath.Ext(value))
-	allowed := []string{".jpg", ".png", ".pdf", ".txt"}
-	for _, a := range allowed {
+	for _, a := range f.allowedExtensions() {
 		if ext == a {
 			return true
 		}
@@ -132,7 +232,7 @@ func (f FileValidator) IsValid(value string) bool {
 }
 
 func (f FileValidator) Description() string {
-	return "File (valid extension)"
+	return "File (valid extension: " + strings.Join(f.allowedExtensions(), ", ") + ")"
 }
 
 // Boolean
@@ -164,47 +264,108 @@ func (s SelectValidator) IsValid(value string) bool {
 }
 
 func (s SelectValidator) Description() string {
-	return "Select (same as radio but can be another list)"
+	return "Select (value must be one of: " + strings.Join(s.Options, ", ") + ")"
 }
 
-// Checkbox (múltiples valores separados por coma, todos deben ser válidos)
-type CheckboxValidator struct {
-	Options []string
+// Selections limita cuántas opciones se pueden marcar; 0 significa sin límite.
+type Selections struct {
+	Min int
+	Max int
 }
 
-func (c CheckboxValidator) Name() string { return string(QuestionTypeCheckbox) }
-func (c CheckboxValidator) IsValid(value string) bool {
-	options := c.Options
-	values := strings.Split(value, ",")
+// IsValid comprueba que los valores sean opciones válidas, sin repetir y dentro
+// del número de selecciones permitido.
+func (s Selections) IsValid(options []string, values []string) bool {
+
 	if len(values) == 0 {
 		return false
 	}
+
+	if s.Min > 0 && len(values) < s.Min {
+		return false
+	}
+
+	if s.Max > 0 && len(values) > s.Max {
+		return false
+	}
+
+	seen := make(map[string]bool, len(values))
 	for _, v := range values {
-		v = strings.TrimSpace(v)
-		found := false
-		for _, o := range options {
-			if v == o {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if seen[v] || !slices.Contains(options, v) {
 			return false
 		}
+		seen[v] = true
 	}
 	return true
 }
 
+func (s Selections) Description() string {
+	switch {
+	case s.Min > 0 && s.Max > 0:
+		return fmt.Sprintf(", between %d and %d selections", s.Min, s.Max)
+	case s.Min > 0:
+		return fmt.Sprintf(", at least %d selections", s.Min)
+	case s.Max > 0:
+		return fmt.Sprintf(", at most %d selections", s.Max)
+	}
+	return ""
+}
+
+// Checkbox (varios valores en values; se acepta también el formato antiguo
+// separado por comas en answer)
+type CheckboxValidator struct {
+	Options    []string
+	Selections Selections
+}
+
+func (c CheckboxValidator) Name() string { return string(QuestionTypeCheckbox) }
+func (c CheckboxValidator) IsValid(value string) bool {
+	values := strings.Split(value, ",")
+	for i, v := range values {
+		values[i] = strings.TrimSpace(v)
+	}
+	return c.IsValidValues(values)
+}
+
+func (c CheckboxValidator) IsValidValues(values []string) bool {
+	return c.Selections.IsValid(c.Options, values)
+}
+
 func (c CheckboxValidator) Description() string {
-	return "Checkbox (multiple values separated by comma, all must be valid)"
+	return "Checkbox (values, each one of: " + strings.Join(c.Options, ", ") + c.Selections.Description() + ")"
+}
+
+// Multi dropdown (como dropdown pero con varios valores en values)
+type MultiDropdownValidator struct {
+	Options    []string
+	Selections Selections
+}
+
+func (d MultiDropdownValidator) Name() string { return string(QuestionTypeMultiDropdown) }
+func (d MultiDropdownValidator) IsValid(value string) bool {
+	return d.IsValidValues([]string{value})
 }
 
-// Dropdown (igual que select pero distinta lista de opciones)
-type DropdownValidator struct{}
+func (d MultiDropdownValidator) IsValidValues(values []string) bool {
+	return d.Selections.IsValid(d.Options, values)
+}
+
+func (d MultiDropdownValidator) Description() string {
+	return "Multi dropdown (values, each one of: " + strings.Join(d.Options, ", ") + d.Selections.Description() + ")"
+}
+
+// LegacyDropdownOptions son las opciones fijas de los dropdowns sin options
+// en la metadata.
+var LegacyDropdownOptions = []string{"uno", "dos", "tres"}
+
+// Dropdown (igual que select, con las opciones de la metadata)
+type DropdownValidator struct {
+	Options []string
+}
 
 func (d DropdownValidator) Name() string { return string(QuestionTypeDropdown) }
 func (d DropdownValidator) IsValid(value string) bool {
-	options := []string{"uno", "dos", "tres"}
+	options := d.Options
 	for _, o := range options {
 		if value == o {
 			return true
@@ -214,33 +375,5 @@ func (d DropdownValidator) IsValid(value string) bool {
 }
 
 func (d DropdownValidator) Description() string {
-	return "Dropdown (same as select but different list of options)"
-}
-
-// Fecha (YYYY-MM-DD)
-type DateValidator struct{}
-
-func (d DateValidator) Name() string { return string(QuestionTypeDate) }
-func (d DateValidator) IsValid(value string) bool {
-	_, err := time.Parse("2006-01-02", value)
-	return err == nil
-}
-
-func (d DateValidator) Description() string {
-	return "Date (YYYY-MM-DD)"
-}
-
-// ========== Mapa de validadores ==========
-var Validators = map[QuestionType]Validator{
-	QuestionTypeText:      TextValidator{},
-	QuestionTypeTextLong:  TextLongValidator{},
-	QuestionTypeTextShort: TextShortValidator{},
-	QuestionTypeTextEmail: EmailValidator{},
-	QuestionTypeRadio:     RadioValidator{},
-	QuestionTypeFile:      FileValidator{},
-	QuestionTypeBoolean:   BooleanValidator{},
-	QuestionTypeSelect:    SelectValidator{},
-	QuestionTypeCheckbox:  CheckboxValidator{},
-	QuestionTypeDropdown:  DropdownValidator{},
-	QuestionTypeDate:      DateValidator{},
+	return "Dropdown (value must be one of: " + strings.Join(d.Options, ", ") + ")"
 }